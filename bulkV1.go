@@ -0,0 +1,399 @@
+package go_salesforce_api_client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// bulkV1Namespace is the XML namespace of Bulk API 1.0 requests and responses
+const bulkV1Namespace = "http://www.force.com/2009/06/asyncapi/dataload"
+
+// Bulk API 1.0 batch states
+const (
+	BulkV1BatchQueued       = "Queued"
+	BulkV1BatchInProgress   = "InProgress"
+	BulkV1BatchCompleted    = "Completed"
+	BulkV1BatchFailed       = "Failed"
+	BulkV1BatchNotProcessed = "Not Processed"
+)
+
+// BulkV1JobOptions configures a Bulk API 1.0 job
+type BulkV1JobOptions struct {
+	Object              string
+	Operation           string // insert, update, upsert, delete, hardDelete, query or queryAll
+	ExternalIDFieldName string // Required for upsert
+	ConcurrencyMode     string // Parallel or Serial
+	ContentType         string // CSV (default), XML, JSON, ZIP_CSV, ZIP_XML or ZIP_JSON
+	PKChunking          *PKChunkingOptions
+}
+
+// PKChunkingOptions enables primary key chunking for Bulk API 1.0 query jobs.
+// An empty value enables chunking with the Salesforce defaults.
+type PKChunkingOptions struct {
+	ChunkSize int    // Records per chunk, up to 250,000
+	Parent    string // Parent object when querying a sharing object, e.g. Account for AccountShare
+	StartRow  string // 15 or 18 character record ID used as the lower boundary of the first chunk
+}
+
+// BulkV1JobInfo represents the state and details of a Bulk API 1.0 job
+type BulkV1JobInfo struct {
+	ID                      string  `xml:"id" json:"id"`
+	Operation               string  `xml:"operation" json:"operation"`
+	Object                  string  `xml:"object" json:"object"`
+	CreatedByID             string  `xml:"createdById" json:"createdById"`
+	CreatedDate             string  `xml:"createdDate" json:"createdDate"`
+	SystemModstamp          string  `xml:"systemModstamp" json:"systemModstamp"`
+	State                   string  `xml:"state" json:"state"`
+	ExternalIDFieldName     string  `xml:"externalIdFieldName" json:"externalIdFieldName"`
+	ConcurrencyMode         string  `xml:"concurrencyMode" json:"concurrencyMode"`
+	ContentType             string  `xml:"contentType" json:"contentType"`
+	NumberBatchesQueued     int     `xml:"numberBatchesQueued" json:"numberBatchesQueued"`
+	NumberBatchesInProgress int     `xml:"numberBatchesInProgress" json:"numberBatchesInProgress"`
+	NumberBatchesCompleted  int     `xml:"numberBatchesCompleted" json:"numberBatchesCompleted"`
+	NumberBatchesFailed     int     `xml:"numberBatchesFailed" json:"numberBatchesFailed"`
+	NumberBatchesTotal      int     `xml:"numberBatchesTotal" json:"numberBatchesTotal"`
+	NumberRecordsProcessed  int     `xml:"numberRecordsProcessed" json:"numberRecordsProcessed"`
+	NumberRecordsFailed     int     `xml:"numberRecordsFailed" json:"numberRecordsFailed"`
+	NumberRetries           int     `xml:"numberRetries" json:"numberRetries"`
+	APIVersion              float64 `xml:"apiVersion" json:"apiVersion"`
+	TotalProcessingTime     int64   `xml:"totalProcessingTime" json:"totalProcessingTime"`
+	APIActiveProcessingTime int64   `xml:"apiActiveProcessingTime" json:"apiActiveProcessingTime"`
+	ApexProcessingTime      int64   `xml:"apexProcessingTime" json:"apexProcessingTime"`
+}
+
+// BulkV1BatchInfo represents the state and details of a Bulk API 1.0 batch
+type BulkV1BatchInfo struct {
+	ID                      string `xml:"id" json:"id"`
+	JobID                   string `xml:"jobId" json:"jobId"`
+	State                   string `xml:"state" json:"state"`
+	StateMessage            string `xml:"stateMessage" json:"stateMessage"`
+	CreatedDate             string `xml:"createdDate" json:"createdDate"`
+	SystemModstamp          string `xml:"systemModstamp" json:"systemModstamp"`
+	NumberRecordsProcessed  int    `xml:"numberRecordsProcessed" json:"numberRecordsProcessed"`
+	NumberRecordsFailed     int    `xml:"numberRecordsFailed" json:"numberRecordsFailed"`
+	TotalProcessingTime     int64  `xml:"totalProcessingTime" json:"totalProcessingTime"`
+	APIActiveProcessingTime int64  `xml:"apiActiveProcessingTime" json:"apiActiveProcessingTime"`
+	ApexProcessingTime      int64  `xml:"apexProcessingTime" json:"apexProcessingTime"`
+}
+
+// bulkV1JobRequest is the jobInfo sent when creating a job; Salesforce requires this element order
+type bulkV1JobRequest struct {
+	XMLName             xml.Name `xml:"http://www.force.com/2009/06/asyncapi/dataload jobInfo"`
+	Operation           string   `xml:"operation"`
+	Object              string   `xml:"object"`
+	ExternalIDFieldName string   `xml:"externalIdFieldName,omitempty"`
+	ConcurrencyMode     string   `xml:"concurrencyMode,omitempty"`
+	ContentType         string   `xml:"contentType"`
+}
+
+// bulkV1StateRequest is the jobInfo sent when changing the state of a job
+type bulkV1StateRequest struct {
+	XMLName xml.Name `xml:"http://www.force.com/2009/06/asyncapi/dataload jobInfo"`
+	State   string   `xml:"state"`
+}
+
+// header returns the Sforce-Enable-PKChunking header value
+func (o PKChunkingOptions) header() string {
+	var parts []string
+	if o.ChunkSize > 0 {
+		parts = append(parts, fmt.Sprintf("chunkSize=%d", o.ChunkSize))
+	}
+	if o.Parent != "" {
+		parts = append(parts, "parent="+o.Parent)
+	}
+	if o.StartRow != "" {
+		parts = append(parts, "startRow="+o.StartRow)
+	}
+	if len(parts) == 0 {
+		return "true"
+	}
+	return strings.Join(parts, "; ")
+}
+
+// bulkV1ContentType returns the HTTP content type of batch data for a job content type
+func bulkV1ContentType(jobContentType string) string {
+	switch strings.ToUpper(jobContentType) {
+	case "XML":
+		return "application/xml; charset=UTF-8"
+	case "JSON":
+		return "application/json"
+	case "ZIP_CSV":
+		return "zip/csv"
+	case "ZIP_XML":
+		return "zip/xml"
+	case "ZIP_JSON":
+		return "zip/json"
+	default:
+		return "text/csv; charset=UTF-8"
+	}
+}
+
+// sendBulkV1Request sends a request to the Bulk API 1.0 and returns the response body and content type
+func (c *Client) sendBulkV1Request(method, path, contentType string, body io.Reader, headers map[string]string) ([]byte, string, error) {
+	if c.AccessToken == "" || c.InstanceURL == "" {
+		return nil, "", errors.New("missing authentication details")
+	}
+
+	url := fmt.Sprintf("%s/services/async/58.0/%s", c.InstanceURL, path)
+
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("X-SFDC-Session", c.AccessToken)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, "", fmt.Errorf("bulk API request failed, status: %d, response: %s", resp.StatusCode, string(responseBody))
+	}
+
+	return responseBody, resp.Header.Get("Content-Type"), nil
+}
+
+// decodeBulkV1Response decodes an XML or JSON Bulk API 1.0 response into v
+func decodeBulkV1Response(body []byte, contentType string, v any) error {
+	if strings.Contains(contentType, "json") {
+		return json.Unmarshal(body, v)
+	}
+	return xml.Unmarshal(body, v)
+}
+
+// CreateBulkV1Job creates a Bulk API 1.0 job
+func (c *Client) CreateBulkV1Job(options BulkV1JobOptions) (*BulkV1JobInfo, error) {
+	contentType := options.ContentType
+	if contentType == "" {
+		contentType = "CSV"
+	}
+
+	xmlData, err := xml.Marshal(bulkV1JobRequest{
+		Operation:           options.Operation,
+		Object:              options.Object,
+		ExternalIDFieldName: options.ExternalIDFieldName,
+		ConcurrencyMode:     options.ConcurrencyMode,
+		ContentType:         contentType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	headers := map[string]string{}
+	if options.PKChunking != nil {
+		headers["Sforce-Enable-PKChunking"] = options.PKChunking.header()
+	}
+
+	body, responseType, err := c.sendBulkV1Request(http.MethodPost, "job", "application/xml; charset=UTF-8", bytes.NewReader(append([]byte(xml.Header), xmlData...)), headers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bulk job: %w", err)
+	}
+
+	var jobInfo BulkV1JobInfo
+	if err := decodeBulkV1Response(body, responseType, &jobInfo); err != nil {
+		return nil, err
+	}
+
+	return &jobInfo, nil
+}
+
+// GetBulkV1Job retrieves the state and details of a Bulk API 1.0 job
+func (c *Client) GetBulkV1Job(jobID string) (*BulkV1JobInfo, error) {
+	body, responseType, err := c.sendBulkV1Request(http.MethodGet, "job/"+jobID, "", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve bulk job: %w", err)
+	}
+
+	var jobInfo BulkV1JobInfo
+	if err := decodeBulkV1Response(body, responseType, &jobInfo); err != nil {
+		return nil, err
+	}
+
+	return &jobInfo, nil
+}
+
+// CloseBulkV1Job closes a job so no more batches can be added
+func (c *Client) CloseBulkV1Job(jobID string) (*BulkV1JobInfo, error) {
+	return c.setBulkV1JobState(jobID, "Closed")
+}
+
+// AbortBulkV1Job aborts a job and its unprocessed batches
+func (c *Client) AbortBulkV1Job(jobID string) (*BulkV1JobInfo, error) {
+	return c.setBulkV1JobState(jobID, "Aborted")
+}
+
+// setBulkV1JobState changes the state of a Bulk API 1.0 job
+func (c *Client) setBulkV1JobState(jobID, state string) (*BulkV1JobInfo, error) {
+	xmlData, err := xml.Marshal(bulkV1StateRequest{State: state})
+	if err != nil {
+		return nil, err
+	}
+
+	body, responseType, err := c.sendBulkV1Request(http.MethodPost, "job/"+jobID, "application/xml; charset=UTF-8", bytes.NewReader(append([]byte(xml.Header), xmlData...)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set bulk job state to %s: %w", state, err)
+	}
+
+	var jobInfo BulkV1JobInfo
+	if err := decodeBulkV1Response(body, responseType, &jobInfo); err != nil {
+		return nil, err
+	}
+
+	return &jobInfo, nil
+}
+
+// AddBulkV1Batch adds a batch of records, or a SOQL query for query jobs, to
+// an open job. jobContentType is the content type the job was created with.
+func (c *Client) AddBulkV1Batch(jobID, jobContentType string, data io.Reader) (*BulkV1BatchInfo, error) {
+	body, responseType, err := c.sendBulkV1Request(http.MethodPost, "job/"+jobID+"/batch", bulkV1ContentType(jobContentType), data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add bulk batch: %w", err)
+	}
+
+	var batchInfo BulkV1BatchInfo
+	if err := decodeBulkV1Response(body, responseType, &batchInfo); err != nil {
+		return nil, err
+	}
+
+	return &batchInfo, nil
+}
+
+// GetBulkV1Batch retrieves the state and details of a batch
+func (c *Client) GetBulkV1Batch(jobID, batchID string) (*BulkV1BatchInfo, error) {
+	body, responseType, err := c.sendBulkV1Request(http.MethodGet, "job/"+jobID+"/batch/"+batchID, "", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve bulk batch: %w", err)
+	}
+
+	var batchInfo BulkV1BatchInfo
+	if err := decodeBulkV1Response(body, responseType, &batchInfo); err != nil {
+		return nil, err
+	}
+
+	return &batchInfo, nil
+}
+
+// GetBulkV1Batches retrieves all batches of a job, including the batches
+// created by PK chunking
+func (c *Client) GetBulkV1Batches(jobID string) ([]BulkV1BatchInfo, error) {
+	body, responseType, err := c.sendBulkV1Request(http.MethodGet, "job/"+jobID+"/batch", "", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve bulk batches: %w", err)
+	}
+
+	var batchList struct {
+		BatchInfo []BulkV1BatchInfo `xml:"batchInfo" json:"batchInfo"`
+	}
+	if err := decodeBulkV1Response(body, responseType, &batchList); err != nil {
+		return nil, err
+	}
+
+	return batchList.BatchInfo, nil
+}
+
+// GetBulkV1BatchResults retrieves the result file of an ingest batch, in the
+// content type of the job
+func (c *Client) GetBulkV1BatchResults(jobID, batchID string) ([]byte, error) {
+	body, _, err := c.sendBulkV1Request(http.MethodGet, "job/"+jobID+"/batch/"+batchID+"/result", "", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve bulk batch results: %w", err)
+	}
+	return body, nil
+}
+
+// GetBulkV1QueryResultIDs retrieves the IDs of the result sets of a query batch
+func (c *Client) GetBulkV1QueryResultIDs(jobID, batchID string) ([]string, error) {
+	body, responseType, err := c.sendBulkV1Request(http.MethodGet, "job/"+jobID+"/batch/"+batchID+"/result", "", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve bulk query result list: %w", err)
+	}
+
+	if strings.Contains(responseType, "json") {
+		var ids []string
+		if err := json.Unmarshal(body, &ids); err != nil {
+			return nil, err
+		}
+		return ids, nil
+	}
+
+	var resultList struct {
+		Results []string `xml:"result"`
+	}
+	if err := xml.Unmarshal(body, &resultList); err != nil {
+		return nil, err
+	}
+	return resultList.Results, nil
+}
+
+// GetBulkV1QueryResult retrieves a result set of a query batch. The caller
+// must close the returned reader.
+func (c *Client) GetBulkV1QueryResult(jobID, batchID, resultID string) (io.ReadCloser, error) {
+	if c.AccessToken == "" || c.InstanceURL == "" {
+		return nil, errors.New("missing authentication details")
+	}
+
+	url := fmt.Sprintf("%s/services/async/58.0/job/%s/batch/%s/result/%s", c.InstanceURL, jobID, batchID, resultID)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-SFDC-Session", c.AccessToken)
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to retrieve bulk query result, status: %d, response: %s", resp.StatusCode, string(body))
+	}
+
+	return resp.Body, nil
+}
+
+// WaitForBulkV1Batches polls the batches of a job until none is queued or in
+// progress and returns their final state. With PK chunking the original
+// batch ends as "Not Processed" while the chunk batches hold the results.
+func (c *Client) WaitForBulkV1Batches(ctx context.Context, jobID string, options WaitOptions) ([]BulkV1BatchInfo, error) {
+	var batches []BulkV1BatchInfo
+	err := poll(ctx, options, func() (bool, error) {
+		var err error
+		batches, err = c.GetBulkV1Batches(jobID)
+		if err != nil {
+			return false, err
+		}
+		for _, batch := range batches {
+			if batch.State == BulkV1BatchQueued || batch.State == BulkV1BatchInProgress {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+
+	return batches, err
+}
@@ -0,0 +1,153 @@
+package go_salesforce_api_client_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	go_salesforce_api_client "github.com/MASA-JAPAN/go-salesforce-api-client"
+)
+
+func TestCreateBulkV1Job_PKChunking(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/async/58.0/job" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if r.Header.Get("X-SFDC-Session") != "test_token" {
+			t.Errorf("Expected session header, got %q", r.Header.Get("X-SFDC-Session"))
+		}
+		if got := r.Header.Get("Sforce-Enable-PKChunking"); got != "chunkSize=50000; parent=Account" {
+			t.Errorf("Unexpected PK chunking header %q", got)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(body), "<operation>query</operation><object>AccountShare</object><concurrencyMode>Serial</concurrencyMode><contentType>CSV</contentType>") {
+			t.Errorf("Unexpected job request: %s", body)
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `<?xml version="1.0" encoding="UTF-8"?>
+<jobInfo xmlns="http://www.force.com/2009/06/asyncapi/dataload">
+  <id>750x0000000005LAAQ</id><operation>query</operation><object>AccountShare</object>
+  <state>Open</state><concurrencyMode>Serial</concurrencyMode><contentType>CSV</contentType><apiVersion>58.0</apiVersion>
+</jobInfo>`)
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	job, err := client.CreateBulkV1Job(go_salesforce_api_client.BulkV1JobOptions{
+		Object:          "AccountShare",
+		Operation:       "query",
+		ConcurrencyMode: "Serial",
+		PKChunking:      &go_salesforce_api_client.PKChunkingOptions{ChunkSize: 50000, Parent: "Account"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if job.ID != "750x0000000005LAAQ" || job.State != "Open" || job.APIVersion != 58.0 {
+		t.Errorf("Unexpected job: %+v", job)
+	}
+}
+
+func TestAddBulkV1Batch_JSON(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Expected JSON batch, got %s", r.Header.Get("Content-Type"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{"id":"751x00000000079AAA","jobId":"750x0000000005LAAQ","state":"Queued"}`)
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	batch, err := client.AddBulkV1Batch("750x0000000005LAAQ", "JSON", strings.NewReader(`[{"Name":"Acme"}]`))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if batch.ID != "751x00000000079AAA" || batch.State != "Queued" {
+		t.Errorf("Unexpected batch: %+v", batch)
+	}
+}
+
+func TestWaitForBulkV1Batches(t *testing.T) {
+	t.Parallel()
+	var polls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		state := "InProgress"
+		if atomic.AddInt32(&polls, 1) > 1 {
+			state = "Completed"
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = io.WriteString(w, `<batchInfoList xmlns="http://www.force.com/2009/06/asyncapi/dataload">
+  <batchInfo><id>751A</id><state>Not Processed</state></batchInfo>
+  <batchInfo><id>751B</id><state>`+state+`</state><numberRecordsProcessed>50000</numberRecordsProcessed></batchInfo>
+</batchInfoList>`)
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	batches, err := client.WaitForBulkV1Batches(context.Background(), "750x0000000005LAAQ", go_salesforce_api_client.WaitOptions{PollInterval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(batches) != 2 || batches[1].State != go_salesforce_api_client.BulkV1BatchCompleted || batches[1].NumberRecordsProcessed != 50000 {
+		t.Errorf("Unexpected batches: %+v", batches)
+	}
+}
+
+func TestGetBulkV1QueryResult(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/services/async/58.0/job/750A/batch/751B/result":
+			w.Header().Set("Content-Type", "application/xml")
+			_, _ = io.WriteString(w, `<result-list xmlns="http://www.force.com/2009/06/asyncapi/dataload"><result>752A</result><result>752B</result></result-list>`)
+		case "/services/async/58.0/job/750A/batch/751B/result/752B":
+			_, _ = io.WriteString(w, "\"Id\",\"Name\"\n\"001A\",\"Acme\"\n")
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	ids, err := client.GetBulkV1QueryResultIDs("750A", "751B")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(ids) != 2 || ids[1] != "752B" {
+		t.Fatalf("Unexpected result IDs: %v", ids)
+	}
+
+	result, err := client.GetBulkV1QueryResult("750A", "751B", ids[1])
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	defer result.Close()
+	data, _ := io.ReadAll(result)
+	if !strings.Contains(string(data), "Acme") {
+		t.Errorf("Unexpected result data: %s", data)
+	}
+}
+
+func TestCloseBulkV1Job_Error(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = io.WriteString(w, `<error xmlns="http://www.force.com/2009/06/asyncapi/dataload"><exceptionCode>InvalidJob</exceptionCode><exceptionMessage>Invalid job id</exceptionMessage></error>`)
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	_, err := client.CloseBulkV1Job("bad")
+	if err == nil || !strings.Contains(err.Error(), "InvalidJob") {
+		t.Fatalf("Expected InvalidJob error, got: %v", err)
+	}
+}
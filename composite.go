@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 )
 
 // CompositeResponse represents the generic Salesforce API response
@@ -163,3 +164,195 @@ func (c *Client) DeleteRecords(objectType string, recordIDs []string) error {
 
 	return nil
 }
+
+// CompositeSubrequest represents a single subrequest of a composite request
+type CompositeSubrequest struct {
+	Method      string            `json:"method"`
+	URL         string            `json:"url"`
+	ReferenceID string            `json:"referenceId"`
+	Body        any               `json:"body,omitempty"`
+	HTTPHeaders map[string]string `json:"httpHeaders,omitempty"`
+}
+
+// CompositeRequest builds a request for the Salesforce Composite API.
+// Subrequests are executed in order and later subrequests can use the
+// output of earlier ones through references like @{newAccount.id}.
+type CompositeRequest struct {
+	AllOrNone          bool
+	CollateSubrequests bool
+	Subrequests        []CompositeSubrequest
+}
+
+// CompositeSubresponse represents the result of a single composite subrequest
+type CompositeSubresponse struct {
+	Body           json.RawMessage   `json:"body"`
+	HTTPHeaders    map[string]string `json:"httpHeaders"`
+	HTTPStatusCode int               `json:"httpStatusCode"`
+	ReferenceID    string            `json:"referenceId"`
+}
+
+// CompositeError represents an error returned by a composite subrequest
+type CompositeError struct {
+	ErrorCode string   `json:"errorCode"`
+	Message   string   `json:"message"`
+	Fields    []string `json:"fields,omitempty"`
+}
+
+// CompositeResult represents the response of the Salesforce Composite API
+type CompositeResult struct {
+	CompositeResponse []CompositeSubresponse `json:"compositeResponse"`
+}
+
+// maxCompositeSubrequests is the number of subrequests allowed in a single composite request
+const maxCompositeSubrequests = 25
+
+// NewCompositeRequest creates an empty composite request
+func NewCompositeRequest(allOrNone bool) *CompositeRequest {
+	return &CompositeRequest{AllOrNone: allOrNone}
+}
+
+// Add appends a subrequest. URLs without a leading slash are treated as
+// relative to /services/data/v58.0/, e.g. "sobjects/Account".
+func (r *CompositeRequest) Add(method, url, referenceID string, body any) *CompositeRequest {
+	return r.AddWithHeaders(method, url, referenceID, body, nil)
+}
+
+// AddWithHeaders appends a subrequest with additional HTTP headers
+func (r *CompositeRequest) AddWithHeaders(method, url, referenceID string, body any, headers map[string]string) *CompositeRequest {
+	r.Subrequests = append(r.Subrequests, CompositeSubrequest{
+		Method:      method,
+		URL:         compositeSubrequestURL(url),
+		ReferenceID: referenceID,
+		Body:        body,
+		HTTPHeaders: headers,
+	})
+	return r
+}
+
+// compositeSubrequestURL expands relative subrequest URLs to full REST API paths
+func compositeSubrequestURL(url string) string {
+	if strings.HasPrefix(url, "/") {
+		return url
+	}
+	return "/services/data/v58.0/" + url
+}
+
+// validateCompositeSubrequests checks that every subrequest has a unique reference ID
+func validateCompositeSubrequests(subrequests []CompositeSubrequest) error {
+	seen := make(map[string]bool, len(subrequests))
+	for i, sr := range subrequests {
+		if sr.ReferenceID == "" {
+			return fmt.Errorf("subrequest %d is missing a referenceId", i)
+		}
+		if seen[sr.ReferenceID] {
+			return fmt.Errorf("duplicate referenceId %q", sr.ReferenceID)
+		}
+		seen[sr.ReferenceID] = true
+	}
+	return nil
+}
+
+// Get returns the subresponse with the given reference ID
+func (r *CompositeResult) Get(referenceID string) (*CompositeSubresponse, bool) {
+	for i := range r.CompositeResponse {
+		if r.CompositeResponse[i].ReferenceID == referenceID {
+			return &r.CompositeResponse[i], true
+		}
+	}
+	return nil, false
+}
+
+// Decode decodes the subresponse body with the given reference ID into v
+func (r *CompositeResult) Decode(referenceID string, v any) error {
+	sr, ok := r.Get(referenceID)
+	if !ok {
+		return fmt.Errorf("no subresponse with referenceId %q", referenceID)
+	}
+	return sr.Decode(v)
+}
+
+// IsSuccess reports whether the subrequest returned a 2xx status code
+func (s *CompositeSubresponse) IsSuccess() bool {
+	return s.HTTPStatusCode >= 200 && s.HTTPStatusCode < 300
+}
+
+// Decode unmarshals the subresponse body into v
+func (s *CompositeSubresponse) Decode(v any) error {
+	if len(s.Body) == 0 {
+		return errors.New("empty subresponse body")
+	}
+	return json.Unmarshal(s.Body, v)
+}
+
+// Errors returns the errors reported by a failed subrequest
+func (s *CompositeSubresponse) Errors() []CompositeError {
+	if s.IsSuccess() || len(s.Body) == 0 {
+		return nil
+	}
+	var errs []CompositeError
+	if err := json.Unmarshal(s.Body, &errs); err != nil {
+		return []CompositeError{{Message: string(s.Body)}}
+	}
+	return errs
+}
+
+// Composite sends a composite request containing up to 25 subrequests
+func (c *Client) Composite(request *CompositeRequest) (*CompositeResult, error) {
+	if c.AccessToken == "" || c.InstanceURL == "" {
+		return nil, errors.New("missing authentication details")
+	}
+
+	if request == nil || len(request.Subrequests) == 0 {
+		return nil, errors.New("composite request has no subrequests")
+	}
+	if len(request.Subrequests) > maxCompositeSubrequests {
+		return nil, fmt.Errorf("composite request has %d subrequests, maximum is %d", len(request.Subrequests), maxCompositeSubrequests)
+	}
+	if err := validateCompositeSubrequests(request.Subrequests); err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/services/data/v58.0/composite", c.InstanceURL)
+
+	requestBody := map[string]interface{}{
+		"allOrNone":          request.AllOrNone,
+		"collateSubrequests": request.CollateSubrequests,
+		"compositeRequest":   request.Subrequests,
+	}
+
+	jsonData, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.AccessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to execute composite request, status: %d, response: %s", resp.StatusCode, string(body))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var result CompositeResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
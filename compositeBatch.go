@@ -0,0 +1,149 @@
+package go_salesforce_api_client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// CompositeBatchSubrequest represents a single subrequest of a batch request
+type CompositeBatchSubrequest struct {
+	Method    string `json:"method"`
+	URL       string `json:"url"`
+	RichInput any    `json:"richInput,omitempty"`
+}
+
+// CompositeBatchRequest builds a request for the Salesforce Composite Batch API.
+// Subrequests are independent of each other and are not rolled back together.
+type CompositeBatchRequest struct {
+	HaltOnError   bool
+	BatchRequests []CompositeBatchSubrequest
+}
+
+// CompositeBatchSubresult represents the result of a single batch subrequest
+type CompositeBatchSubresult struct {
+	StatusCode int             `json:"statusCode"`
+	Result     json.RawMessage `json:"result"`
+}
+
+// CompositeBatchResult represents the response of the Salesforce Composite Batch API.
+// Results are in the same order as the subrequests.
+type CompositeBatchResult struct {
+	HasErrors bool                      `json:"hasErrors"`
+	Results   []CompositeBatchSubresult `json:"results"`
+}
+
+// maxCompositeBatchSubrequests is the number of subrequests allowed in a single batch request
+const maxCompositeBatchSubrequests = 25
+
+// NewCompositeBatchRequest creates an empty batch request
+func NewCompositeBatchRequest(haltOnError bool) *CompositeBatchRequest {
+	return &CompositeBatchRequest{HaltOnError: haltOnError}
+}
+
+// Add appends a subrequest. URLs may be given as "sobjects/Account/001...",
+// "v58.0/sobjects/Account/001..." or "/services/data/v58.0/sobjects/Account/001...".
+func (r *CompositeBatchRequest) Add(method, url string, richInput any) *CompositeBatchRequest {
+	r.BatchRequests = append(r.BatchRequests, CompositeBatchSubrequest{
+		Method:    method,
+		URL:       compositeBatchSubrequestURL(url),
+		RichInput: richInput,
+	})
+	return r
+}
+
+// compositeBatchSubrequestURL converts a URL to the version-relative form the Batch API expects
+func compositeBatchSubrequestURL(url string) string {
+	url = strings.TrimPrefix(url, "/services/data/")
+	url = strings.TrimPrefix(url, "/")
+	if len(url) > 1 && url[0] == 'v' && url[1] >= '0' && url[1] <= '9' {
+		return url
+	}
+	return "v58.0/" + url
+}
+
+// IsSuccess reports whether the subrequest returned a 2xx status code
+func (s *CompositeBatchSubresult) IsSuccess() bool {
+	return s.StatusCode >= 200 && s.StatusCode < 300
+}
+
+// Decode unmarshals the subrequest result into v
+func (s *CompositeBatchSubresult) Decode(v any) error {
+	if len(s.Result) == 0 || string(s.Result) == "null" {
+		return errors.New("empty subrequest result")
+	}
+	return json.Unmarshal(s.Result, v)
+}
+
+// Errors returns the errors reported by a failed subrequest
+func (s *CompositeBatchSubresult) Errors() []CompositeError {
+	if s.IsSuccess() || len(s.Result) == 0 {
+		return nil
+	}
+	var errs []CompositeError
+	if err := json.Unmarshal(s.Result, &errs); err != nil {
+		return []CompositeError{{Message: string(s.Result)}}
+	}
+	return errs
+}
+
+// CompositeBatch sends up to 25 independent subrequests in a single round trip
+func (c *Client) CompositeBatch(request *CompositeBatchRequest) (*CompositeBatchResult, error) {
+	if c.AccessToken == "" || c.InstanceURL == "" {
+		return nil, errors.New("missing authentication details")
+	}
+
+	if request == nil || len(request.BatchRequests) == 0 {
+		return nil, errors.New("batch request has no subrequests")
+	}
+	if len(request.BatchRequests) > maxCompositeBatchSubrequests {
+		return nil, fmt.Errorf("batch request has %d subrequests, maximum is %d", len(request.BatchRequests), maxCompositeBatchSubrequests)
+	}
+
+	url := fmt.Sprintf("%s/services/data/v58.0/composite/batch", c.InstanceURL)
+
+	requestBody := map[string]interface{}{
+		"haltOnError":   request.HaltOnError,
+		"batchRequests": request.BatchRequests,
+	}
+
+	jsonData, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.AccessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to execute batch request, status: %d, response: %s", resp.StatusCode, string(body))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var result CompositeBatchResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,82 @@
+package go_salesforce_api_client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCompositeBatch(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/data/v58.0/composite/batch" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		var payload struct {
+			HaltOnError   bool                       `json:"haltOnError"`
+			BatchRequests []CompositeBatchSubrequest `json:"batchRequests"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("Failed to decode request: %s", err)
+		}
+		if payload.HaltOnError {
+			t.Error("Expected haltOnError to be false")
+		}
+		expectedURLs := []string{"v58.0/sobjects/Account/001000000000001", "v58.0/limits", "v57.0/sobjects/Contact/describe"}
+		for i, expected := range expectedURLs {
+			if payload.BatchRequests[i].URL != expected {
+				t.Errorf("Expected URL %s, got %s", expected, payload.BatchRequests[i].URL)
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"hasErrors":true,"results":[
+			{"statusCode":404,"result":[{"errorCode":"NOT_FOUND","message":"The requested resource does not exist"}]},
+			{"statusCode":200,"result":{"DailyApiRequests":{"Max":15000,"Remaining":14998}}},
+			{"statusCode":200,"result":{"name":"Contact"}}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := &Client{AccessToken: "mock_token", InstanceURL: server.URL}
+	request := NewCompositeBatchRequest(false).
+		Add(http.MethodGet, "sobjects/Account/001000000000001", nil).
+		Add(http.MethodGet, "/services/data/v58.0/limits", nil).
+		Add(http.MethodGet, "v57.0/sobjects/Contact/describe", nil)
+
+	result, err := client.CompositeBatch(request)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !result.HasErrors {
+		t.Error("Expected hasErrors to be true")
+	}
+
+	if result.Results[0].IsSuccess() {
+		t.Error("Expected first subrequest to fail")
+	}
+	if errs := result.Results[0].Errors(); len(errs) != 1 || errs[0].ErrorCode != "NOT_FOUND" {
+		t.Errorf("Unexpected errors: %+v", errs)
+	}
+
+	var limits LimitsResponse
+	if err := result.Results[1].Decode(&limits); err != nil {
+		t.Fatalf("Expected no decode error, got %v", err)
+	}
+	if _, ok := limits["DailyApiRequests"]; !ok {
+		t.Error("Expected DailyApiRequests in limits")
+	}
+}
+
+func TestCompositeBatch_TooManySubrequests(t *testing.T) {
+	t.Parallel()
+	client := &Client{AccessToken: "mock_token", InstanceURL: "http://localhost"}
+	request := NewCompositeBatchRequest(true)
+	for i := 0; i < 26; i++ {
+		request.Add(http.MethodGet, "limits", nil)
+	}
+
+	if _, err := client.CompositeBatch(request); err == nil {
+		t.Fatal("Expected error for more than 25 subrequests")
+	}
+}
@@ -0,0 +1,254 @@
+package go_salesforce_api_client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+)
+
+// CompositeGraph represents a single graph of a composite graph request.
+// All nodes of a graph succeed or fail together.
+type CompositeGraph struct {
+	GraphID          string                `json:"graphId"`
+	CompositeRequest []CompositeSubrequest `json:"compositeRequest"`
+}
+
+// CompositeGraphRequest builds a request for the Salesforce Composite Graph API
+type CompositeGraphRequest struct {
+	Graphs []*CompositeGraph
+}
+
+// CompositeGraphResponse represents the result of a single graph
+type CompositeGraphResponse struct {
+	GraphID       string          `json:"graphId"`
+	GraphResponse CompositeResult `json:"graphResponse"`
+	IsSuccessful  bool            `json:"isSuccessful"`
+}
+
+// CompositeGraphResult represents the response of the Salesforce Composite Graph API
+type CompositeGraphResult struct {
+	Graphs []CompositeGraphResponse `json:"graphs"`
+}
+
+// maxCompositeGraphNodes is the number of nodes allowed in a single graph
+const maxCompositeGraphNodes = 500
+
+// processingHaltedCode is reported by nodes that were skipped because another node failed
+const processingHaltedCode = "PROCESSING_HALTED"
+
+var (
+	compositeReferenceIDPattern = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+	compositeReferencePattern   = regexp.MustCompile(`@\{([A-Za-z0-9_]+)`)
+)
+
+// NewCompositeGraphRequest creates an empty composite graph request
+func NewCompositeGraphRequest() *CompositeGraphRequest {
+	return &CompositeGraphRequest{}
+}
+
+// AddGraph appends a new graph and returns it so nodes can be added to it
+func (r *CompositeGraphRequest) AddGraph(graphID string) *CompositeGraph {
+	graph := &CompositeGraph{GraphID: graphID}
+	r.Graphs = append(r.Graphs, graph)
+	return graph
+}
+
+// Add appends a node to the graph. URLs without a leading slash are treated
+// as relative to /services/data/v58.0/.
+func (g *CompositeGraph) Add(method, url, referenceID string, body any) *CompositeGraph {
+	g.CompositeRequest = append(g.CompositeRequest, CompositeSubrequest{
+		Method:      method,
+		URL:         compositeSubrequestURL(url),
+		ReferenceID: referenceID,
+		Body:        body,
+	})
+	return g
+}
+
+// Validate checks graph IDs, node counts and that every @{reference}
+// points to a node defined earlier in the same graph
+func (r *CompositeGraphRequest) Validate() error {
+	if len(r.Graphs) == 0 {
+		return errors.New("composite graph request has no graphs")
+	}
+
+	graphIDs := make(map[string]bool, len(r.Graphs))
+	for _, graph := range r.Graphs {
+		if graph.GraphID == "" {
+			return errors.New("graph is missing a graphId")
+		}
+		if graphIDs[graph.GraphID] {
+			return fmt.Errorf("duplicate graphId %q", graph.GraphID)
+		}
+		graphIDs[graph.GraphID] = true
+
+		if len(graph.CompositeRequest) == 0 {
+			return fmt.Errorf("graph %q has no nodes", graph.GraphID)
+		}
+		if len(graph.CompositeRequest) > maxCompositeGraphNodes {
+			return fmt.Errorf("graph %q has %d nodes, maximum is %d", graph.GraphID, len(graph.CompositeRequest), maxCompositeGraphNodes)
+		}
+
+		defined := make(map[string]bool, len(graph.CompositeRequest))
+		for _, node := range graph.CompositeRequest {
+			if !compositeReferenceIDPattern.MatchString(node.ReferenceID) {
+				return fmt.Errorf("graph %q: invalid referenceId %q", graph.GraphID, node.ReferenceID)
+			}
+			if defined[node.ReferenceID] {
+				return fmt.Errorf("graph %q: duplicate referenceId %q", graph.GraphID, node.ReferenceID)
+			}
+
+			refs, err := compositeReferences(node)
+			if err != nil {
+				return fmt.Errorf("graph %q: %w", graph.GraphID, err)
+			}
+			for _, ref := range refs {
+				if !defined[ref] {
+					return fmt.Errorf("graph %q: node %q references undefined node %q", graph.GraphID, node.ReferenceID, ref)
+				}
+			}
+
+			defined[node.ReferenceID] = true
+		}
+	}
+
+	return nil
+}
+
+// compositeReferences returns the reference IDs used by a node's URL and body
+func compositeReferences(node CompositeSubrequest) ([]string, error) {
+	text := node.URL
+	if node.Body != nil {
+		body, err := json.Marshal(node.Body)
+		if err != nil {
+			return nil, fmt.Errorf("node %q: %w", node.ReferenceID, err)
+		}
+		text += string(body)
+	}
+
+	var refs []string
+	for _, match := range compositeReferencePattern.FindAllStringSubmatch(text, -1) {
+		refs = append(refs, match[1])
+	}
+	return refs, nil
+}
+
+// Subset returns a request containing only the graphs with the given IDs,
+// which is useful for retrying failed graphs
+func (r *CompositeGraphRequest) Subset(graphIDs ...string) *CompositeGraphRequest {
+	wanted := make(map[string]bool, len(graphIDs))
+	for _, id := range graphIDs {
+		wanted[id] = true
+	}
+
+	subset := NewCompositeGraphRequest()
+	for _, graph := range r.Graphs {
+		if wanted[graph.GraphID] {
+			subset.Graphs = append(subset.Graphs, graph)
+		}
+	}
+	return subset
+}
+
+// Get returns the result of the graph with the given ID
+func (r *CompositeGraphResult) Get(graphID string) (*CompositeGraphResponse, bool) {
+	for i := range r.Graphs {
+		if r.Graphs[i].GraphID == graphID {
+			return &r.Graphs[i], true
+		}
+	}
+	return nil, false
+}
+
+// FailedGraphIDs returns the IDs of all graphs that were rolled back
+func (r *CompositeGraphResult) FailedGraphIDs() []string {
+	var ids []string
+	for _, graph := range r.Graphs {
+		if !graph.IsSuccessful {
+			ids = append(ids, graph.GraphID)
+		}
+	}
+	return ids
+}
+
+// FailedNode returns the node that caused the graph to fail, skipping
+// nodes that only report PROCESSING_HALTED
+func (g *CompositeGraphResponse) FailedNode() (*CompositeSubresponse, bool) {
+	if g.IsSuccessful {
+		return nil, false
+	}
+
+	for i := range g.GraphResponse.CompositeResponse {
+		node := &g.GraphResponse.CompositeResponse[i]
+		if node.IsSuccess() {
+			continue
+		}
+		errs := node.Errors()
+		if len(errs) > 0 && errs[0].ErrorCode == processingHaltedCode {
+			continue
+		}
+		return node, true
+	}
+	return nil, false
+}
+
+// CompositeGraph sends a composite graph request. Each graph is committed or
+// rolled back independently, so the result must be checked per graph.
+func (c *Client) CompositeGraph(request *CompositeGraphRequest) (*CompositeGraphResult, error) {
+	if c.AccessToken == "" || c.InstanceURL == "" {
+		return nil, errors.New("missing authentication details")
+	}
+
+	if request == nil {
+		return nil, errors.New("composite graph request is nil")
+	}
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/services/data/v58.0/composite/graph", c.InstanceURL)
+
+	requestBody := map[string]interface{}{
+		"graphs": request.Graphs,
+	}
+
+	jsonData, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.AccessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to execute composite graph request, status: %d, response: %s", resp.StatusCode, string(body))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var result CompositeGraphResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,106 @@
+package go_salesforce_api_client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCompositeGraph(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/data/v58.0/composite/graph" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		var payload struct {
+			Graphs []CompositeGraph `json:"graphs"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("Failed to decode request: %s", err)
+		}
+		if len(payload.Graphs) != 2 {
+			t.Fatalf("Expected 2 graphs, got %d", len(payload.Graphs))
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"graphs":[
+			{"graphId":"order1","isSuccessful":true,"graphResponse":{"compositeResponse":[
+				{"body":{"id":"001000000000001","success":true,"errors":[]},"httpStatusCode":201,"referenceId":"account"},
+				{"body":{"id":"801000000000001","success":true,"errors":[]},"httpStatusCode":201,"referenceId":"order"}
+			]}},
+			{"graphId":"order2","isSuccessful":false,"graphResponse":{"compositeResponse":[
+				{"body":[{"errorCode":"PROCESSING_HALTED","message":"The transaction was rolled back since another operation in the same transaction failed."}],"httpStatusCode":400,"referenceId":"account"},
+				{"body":[{"errorCode":"FIELD_INTEGRITY_EXCEPTION","message":"Effective Date: invalid date"}],"httpStatusCode":400,"referenceId":"order"}
+			]}}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := &Client{AccessToken: "mock_token", InstanceURL: server.URL}
+	request := NewCompositeGraphRequest()
+	for _, id := range []string{"order1", "order2"} {
+		request.AddGraph(id).
+			Add(http.MethodPost, "sobjects/Account", "account", map[string]any{"Name": id}).
+			Add(http.MethodPost, "sobjects/Order", "order", map[string]any{"AccountId": "@{account.id}"})
+	}
+
+	result, err := client.CompositeGraph(request)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	failed := result.FailedGraphIDs()
+	if len(failed) != 1 || failed[0] != "order2" {
+		t.Fatalf("Expected order2 to fail, got %v", failed)
+	}
+
+	graph, ok := result.Get("order2")
+	if !ok {
+		t.Fatal("Expected order2 graph result")
+	}
+	node, ok := graph.FailedNode()
+	if !ok {
+		t.Fatal("Expected a failed node")
+	}
+	if node.ReferenceID != "order" {
+		t.Errorf("Expected failing node order, got %s", node.ReferenceID)
+	}
+
+	retry := request.Subset(failed...)
+	if len(retry.Graphs) != 1 || retry.Graphs[0].GraphID != "order2" {
+		t.Errorf("Unexpected retry subset: %+v", retry.Graphs)
+	}
+}
+
+func TestCompositeGraphRequest_Validate(t *testing.T) {
+	t.Parallel()
+
+	undefined := NewCompositeGraphRequest()
+	undefined.AddGraph("g1").
+		Add(http.MethodPost, "sobjects/Contact", "contact", map[string]any{"AccountId": "@{account.id}"}).
+		Add(http.MethodPost, "sobjects/Account", "account", map[string]any{"Name": "Acme"})
+	if err := undefined.Validate(); err == nil {
+		t.Error("Expected error for reference to a later node")
+	}
+
+	invalid := NewCompositeGraphRequest()
+	invalid.AddGraph("g1").Add(http.MethodPost, "sobjects/Account", "new.account", nil)
+	if err := invalid.Validate(); err == nil {
+		t.Error("Expected error for invalid referenceId")
+	}
+
+	duplicate := NewCompositeGraphRequest()
+	duplicate.AddGraph("g1").Add(http.MethodPost, "sobjects/Account", "a", nil)
+	duplicate.AddGraph("g1").Add(http.MethodPost, "sobjects/Account", "a", nil)
+	if err := duplicate.Validate(); err == nil {
+		t.Error("Expected error for duplicate graphId")
+	}
+
+	valid := NewCompositeGraphRequest()
+	valid.AddGraph("g1").
+		Add(http.MethodPost, "sobjects/Account", "account", map[string]any{"Name": "Acme"}).
+		Add(http.MethodGet, "sobjects/Account/@{account.id}", "readBack", nil)
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Expected valid request, got %v", err)
+	}
+}
@@ -62,3 +62,77 @@ func TestDeleteRecords(t *testing.T) {
 		t.Fatalf("Expected no error, got %v", err)
 	}
 }
+
+func TestComposite(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/data/v58.0/composite" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		var payload struct {
+			AllOrNone          bool                  `json:"allOrNone"`
+			CollateSubrequests bool                  `json:"collateSubrequests"`
+			CompositeRequest   []CompositeSubrequest `json:"compositeRequest"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("Failed to decode request: %s", err)
+		}
+		if !payload.AllOrNone {
+			t.Error("Expected allOrNone to be true")
+		}
+		if len(payload.CompositeRequest) != 2 {
+			t.Fatalf("Expected 2 subrequests, got %d", len(payload.CompositeRequest))
+		}
+		if payload.CompositeRequest[0].URL != "/services/data/v58.0/sobjects/Account" {
+			t.Errorf("Expected expanded URL, got %s", payload.CompositeRequest[0].URL)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"compositeResponse":[
+			{"body":{"id":"001000000000001","success":true,"errors":[]},"httpHeaders":{"Location":"/services/data/v58.0/sobjects/Account/001000000000001"},"httpStatusCode":201,"referenceId":"newAccount"},
+			{"body":[{"errorCode":"REQUIRED_FIELD_MISSING","message":"Required fields are missing: [LastName]","fields":["LastName"]}],"httpHeaders":{},"httpStatusCode":400,"referenceId":"newContact"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := &Client{AccessToken: "mock_token", InstanceURL: server.URL}
+	request := NewCompositeRequest(true).
+		Add(http.MethodPost, "sobjects/Account", "newAccount", map[string]any{"Name": "Acme"}).
+		Add(http.MethodPost, "/services/data/v58.0/sobjects/Contact", "newContact", map[string]any{"AccountId": "@{newAccount.id}"})
+
+	result, err := client.Composite(request)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var created SobjectResponse
+	if err := result.Decode("newAccount", &created); err != nil {
+		t.Fatalf("Expected no decode error, got %v", err)
+	}
+	if created.ID != "001000000000001" {
+		t.Errorf("Expected ID 001000000000001, got %s", created.ID)
+	}
+
+	contact, ok := result.Get("newContact")
+	if !ok {
+		t.Fatal("Expected newContact subresponse")
+	}
+	if contact.IsSuccess() {
+		t.Error("Expected newContact to fail")
+	}
+	errs := contact.Errors()
+	if len(errs) != 1 || errs[0].ErrorCode != "REQUIRED_FIELD_MISSING" {
+		t.Errorf("Unexpected errors: %+v", errs)
+	}
+}
+
+func TestComposite_DuplicateReferenceID(t *testing.T) {
+	t.Parallel()
+	client := &Client{AccessToken: "mock_token", InstanceURL: "http://localhost"}
+	request := NewCompositeRequest(false).
+		Add(http.MethodGet, "sobjects/Account/001", "ref", nil).
+		Add(http.MethodGet, "sobjects/Account/002", "ref", nil)
+
+	if _, err := client.Composite(request); err == nil {
+		t.Fatal("Expected error for duplicate referenceId")
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
@@ -31,21 +32,19 @@ func main() {
 
 	// Wait for Job Completion
 	jobID := jobResponse.ID
-	for {
-		jobDetails, err := client.GetJobQuery(jobID)
-		if err != nil {
-			log.Fatalf("Failed to get job query details: %v", err)
-		}
-
-		fmt.Printf("Job Details: %+v\n", jobDetails)
-
-		if jobDetails.State == "JobComplete" {
-			break
-		}
-
-		fmt.Println("Waiting for job to complete...")
-		time.Sleep(5 * time.Second)
+	jobDetails, err := client.WaitForJobQuery(context.Background(), jobID, go_salesforce_api_client.JobQueryWaitOptions{
+		WaitOptions: go_salesforce_api_client.WaitOptions{
+			PollInterval: 5 * time.Second,
+			Timeout:      30 * time.Minute,
+		},
+		OnProgress: func(job *go_salesforce_api_client.JobQueryResponse) {
+			fmt.Printf("Job %s: %s (%d records processed)\n", job.ID, job.State, job.NumberRecordsProcessed)
+		},
+	})
+	if err != nil {
+		log.Fatalf("Job query did not complete: %v", err)
 	}
+	fmt.Printf("Job Details: %+v\n", jobDetails)
 
 	// Fetch and Print Parsed Results
 	var allResults []go_salesforce_api_client.JobQueryResult
@@ -53,11 +53,7 @@ func CreateFieldExample() {
 	// Example: Creating a new Custom Field in Salesforce
 	fieldData := go_salesforce_api_client.CustomField{
 		FullName: "Account.Custom_Field__c",
-		Metadata: struct {
-			Label  string `json:"label"`
-			Type   string `json:"type"`
-			Length int    `json:"length,omitempty"`
-		}{
+		Metadata: go_salesforce_api_client.CustomFieldMetadata{
 			Label:  "Custom Field",
 			Type:   "Text",
 			Length: 255,
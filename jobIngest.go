@@ -0,0 +1,517 @@
+package go_salesforce_api_client
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Bulk API 2.0 ingest operations
+const (
+	IngestOperationInsert     = "insert"
+	IngestOperationUpdate     = "update"
+	IngestOperationUpsert     = "upsert"
+	IngestOperationDelete     = "delete"
+	IngestOperationHardDelete = "hardDelete"
+)
+
+// defaultIngestUploadBytes is the default size of a single upload. The 150 MB
+// limit applies to the base64-encoded data, so raw CSV is kept at 100 MB.
+const defaultIngestUploadBytes = 100 * 1024 * 1024
+
+// IngestJobOptions configures a Bulk API 2.0 ingest job
+type IngestJobOptions struct {
+	Object              string
+	Operation           string // insert, update, upsert, delete, hardDelete
+	ExternalIDFieldName string // Required for upsert
+	LineEnding          string // LF or CRLF
+	ColumnDelimiter     string // COMMA, TAB, PIPE, SEMICOLON, CARET, BACKQUOTE
+}
+
+// IngestJobInfo represents the state and details of a Bulk API 2.0 ingest job
+type IngestJobInfo struct {
+	ID                      string  `json:"id"`
+	Operation               string  `json:"operation"`
+	Object                  string  `json:"object"`
+	CreatedByID             string  `json:"createdById"`
+	CreatedDate             string  `json:"createdDate"`
+	SystemModstamp          string  `json:"systemModstamp"`
+	State                   string  `json:"state"`
+	ExternalIDFieldName     string  `json:"externalIdFieldName"`
+	ConcurrencyMode         string  `json:"concurrencyMode"`
+	ContentType             string  `json:"contentType"`
+	APIVersion              float64 `json:"apiVersion"`
+	JobType                 string  `json:"jobType"`
+	LineEnding              string  `json:"lineEnding"`
+	ColumnDelimiter         string  `json:"columnDelimiter"`
+	NumberRecordsProcessed  int     `json:"numberRecordsProcessed"`
+	NumberRecordsFailed     int     `json:"numberRecordsFailed"`
+	Retries                 int     `json:"retries"`
+	TotalProcessingTime     int64   `json:"totalProcessingTime"`
+	APIActiveProcessingTime int64   `json:"apiActiveProcessingTime"`
+	ApexProcessingTime      int64   `json:"apexProcessingTime"`
+	ErrorMessage            string  `json:"errorMessage"`
+}
+
+// IngestJobResult represents a single row of an ingest job result file
+type IngestJobResult map[string]string
+
+// ID returns the sf__Id column of the result row
+func (r IngestJobResult) ID() string {
+	return r["sf__Id"]
+}
+
+// Created reports whether the row created a new record
+func (r IngestJobResult) Created() bool {
+	return r["sf__Created"] == "true"
+}
+
+// Error returns the sf__Error column of a failed result row
+func (r IngestJobResult) Error() string {
+	return r["sf__Error"]
+}
+
+// columnDelimiters maps Bulk API column delimiter names to characters
+var columnDelimiters = map[string]rune{
+	ColumnDelimiterComma:     ',',
+	ColumnDelimiterTab:       '\t',
+	ColumnDelimiterPipe:      '|',
+	ColumnDelimiterSemicolon: ';',
+	ColumnDelimiterCaret:     '^',
+	ColumnDelimiterBackquote: '`',
+}
+
+// columnDelimiterRune returns the character for a Bulk API column delimiter name
+func columnDelimiterRune(name string) (rune, error) {
+	if name == "" {
+		return ',', nil
+	}
+	r, ok := columnDelimiters[strings.ToUpper(name)]
+	if !ok {
+		return 0, fmt.Errorf("unsupported column delimiter %q", name)
+	}
+	return r, nil
+}
+
+// detectColumnDelimiter finds the delimiter used in a CSV header line.
+// Salesforce field names never contain delimiter characters, so the first
+// one found outside of quotes is the delimiter.
+func detectColumnDelimiter(header string) (rune, bool) {
+	inQuotes := false
+	for _, r := range header {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case inQuotes:
+		case r == ',' || r == '\t' || r == '|' || r == ';' || r == '^' || r == '`':
+			return r, true
+		}
+	}
+	return ',', false
+}
+
+// newBulkCSVReader returns a CSV reader using the delimiter detected from the header line
+func newBulkCSVReader(r io.Reader) *csv.Reader {
+	br := bufio.NewReader(r)
+	header, _ := br.ReadString('\n')
+
+	reader := csv.NewReader(io.MultiReader(strings.NewReader(header), br))
+	if delimiter, ok := detectColumnDelimiter(header); ok {
+		reader.Comma = delimiter
+	} else if strings.TrimSpace(header) != "" {
+		// A single column has no delimiter in its header, and its values may
+		// contain any of the supported delimiters unquoted
+		reader.Comma = '\x1f'
+	}
+	return reader
+}
+
+// CreateIngestJob creates a Bulk API 2.0 ingest job
+func (c *Client) CreateIngestJob(options IngestJobOptions) (*IngestJobInfo, error) {
+	if c.AccessToken == "" || c.InstanceURL == "" {
+		return nil, errors.New("missing authentication details")
+	}
+
+	if _, err := columnDelimiterRune(options.ColumnDelimiter); err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/services/data/v58.0/jobs/ingest", c.InstanceURL)
+
+	requestBody := map[string]interface{}{
+		"object":      options.Object,
+		"operation":   options.Operation,
+		"contentType": "CSV",
+	}
+	if options.ExternalIDFieldName != "" {
+		requestBody["externalIdFieldName"] = options.ExternalIDFieldName
+	}
+	if options.LineEnding != "" {
+		requestBody["lineEnding"] = options.LineEnding
+	}
+	if options.ColumnDelimiter != "" {
+		requestBody["columnDelimiter"] = strings.ToUpper(options.ColumnDelimiter)
+	}
+
+	jsonData, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.AccessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to create ingest job, status: %d, response: %s", resp.StatusCode, string(body))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var jobInfo IngestJobInfo
+	if err := json.Unmarshal(body, &jobInfo); err != nil {
+		return nil, err
+	}
+
+	return &jobInfo, nil
+}
+
+// GetIngestJob retrieves the state and details of an ingest job
+func (c *Client) GetIngestJob(jobID string) (*IngestJobInfo, error) {
+	if c.AccessToken == "" || c.InstanceURL == "" {
+		return nil, errors.New("missing authentication details")
+	}
+
+	url := fmt.Sprintf("%s/services/data/v58.0/jobs/ingest/%s", c.InstanceURL, jobID)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.AccessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to retrieve ingest job, status: %d, response: %s", resp.StatusCode, string(body))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var jobInfo IngestJobInfo
+	if err := json.Unmarshal(body, &jobInfo); err != nil {
+		return nil, err
+	}
+
+	return &jobInfo, nil
+}
+
+// UploadIngestJobData uploads CSV data to an open ingest job. A job accepts a
+// single upload; use IngestCSV to split large files across several jobs.
+func (c *Client) UploadIngestJobData(jobID string, data io.Reader) error {
+	if c.AccessToken == "" || c.InstanceURL == "" {
+		return errors.New("missing authentication details")
+	}
+
+	url := fmt.Sprintf("%s/services/data/v58.0/jobs/ingest/%s/batches", c.InstanceURL, jobID)
+
+	req, err := http.NewRequest(http.MethodPut, url, data)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.AccessToken)
+	req.Header.Set("Content-Type", "text/csv")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to upload ingest job data, status: %d, response: %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// CloseIngestJob marks the upload as complete so Salesforce starts processing the job
+func (c *Client) CloseIngestJob(jobID string) error {
+	return c.setIngestJobState(jobID, "UploadComplete")
+}
+
+// AbortIngestJob aborts an ingest job
+func (c *Client) AbortIngestJob(jobID string) error {
+	return c.setIngestJobState(jobID, "Aborted")
+}
+
+// setIngestJobState changes the state of an ingest job
+func (c *Client) setIngestJobState(jobID, state string) error {
+	if c.AccessToken == "" || c.InstanceURL == "" {
+		return errors.New("missing authentication details")
+	}
+
+	url := fmt.Sprintf("%s/services/data/v58.0/jobs/ingest/%s", c.InstanceURL, jobID)
+
+	requestBody := map[string]string{
+		"state": state,
+	}
+
+	jsonData, err := json.Marshal(requestBody)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPatch, url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.AccessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to set ingest job state to %s, status: %d, response: %s", state, resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// DeleteIngestJob permanently removes an ingest job and its data
+func (c *Client) DeleteIngestJob(jobID string) error {
+	if c.AccessToken == "" || c.InstanceURL == "" {
+		return errors.New("missing authentication details")
+	}
+
+	url := fmt.Sprintf("%s/services/data/v58.0/jobs/ingest/%s", c.InstanceURL, jobID)
+
+	req, err := http.NewRequest(http.MethodDelete, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.AccessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to delete ingest job, status: %d, response: %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// GetIngestJobSuccessfulResults retrieves the records processed successfully,
+// including the sf__Id and sf__Created columns
+func (c *Client) GetIngestJobSuccessfulResults(jobID string) ([]IngestJobResult, error) {
+	return c.getIngestJobResults(jobID, "successfulResults")
+}
+
+// GetIngestJobFailedResults retrieves the records that failed, including the sf__Error column
+func (c *Client) GetIngestJobFailedResults(jobID string) ([]IngestJobResult, error) {
+	return c.getIngestJobResults(jobID, "failedResults")
+}
+
+// GetIngestJobUnprocessedRecords retrieves the records that were not processed
+func (c *Client) GetIngestJobUnprocessedRecords(jobID string) ([]IngestJobResult, error) {
+	return c.getIngestJobResults(jobID, "unprocessedrecords")
+}
+
+// getIngestJobResults retrieves and parses one of the ingest job result files
+func (c *Client) getIngestJobResults(jobID, resultType string) ([]IngestJobResult, error) {
+	if c.AccessToken == "" || c.InstanceURL == "" {
+		return nil, errors.New("missing authentication details")
+	}
+
+	url := fmt.Sprintf("%s/services/data/v58.0/jobs/ingest/%s/%s/", c.InstanceURL, jobID, resultType)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.AccessToken)
+	req.Header.Set("Accept", "text/csv")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to retrieve ingest job %s, status: %d, response: %s", resultType, resp.StatusCode, string(body))
+	}
+
+	records, err := newBulkCSVReader(resp.Body).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	// A job without matching records returns only the header or nothing at all
+	if len(records) < 2 {
+		return []IngestJobResult{}, nil
+	}
+
+	headers := records[0]
+	results := make([]IngestJobResult, 0, len(records)-1)
+	for _, row := range records[1:] {
+		entry := make(IngestJobResult, len(headers))
+		for i, value := range row {
+			entry[headers[i]] = value
+		}
+		results = append(results, entry)
+	}
+
+	return results, nil
+}
+
+// IngestCSV loads CSV data through one or more ingest jobs. The data is split
+// into uploads of at most maxUploadBytes (100 MB when zero or negative), each
+// with the header row repeated, and every job is closed after its upload.
+// A job whose upload or close fails is aborted. The created jobs are returned
+// even when a later upload fails.
+func (c *Client) IngestCSV(options IngestJobOptions, data io.Reader, maxUploadBytes int) ([]*IngestJobInfo, error) {
+	if maxUploadBytes <= 0 {
+		maxUploadBytes = defaultIngestUploadBytes
+	}
+
+	delimiter, err := columnDelimiterRune(options.ColumnDelimiter)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(data)
+	reader.Comma = delimiter
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("empty CSV data")
+		}
+		return nil, err
+	}
+
+	encode := func(buf *bytes.Buffer, record []string) error {
+		writer := csv.NewWriter(buf)
+		writer.Comma = delimiter
+		writer.UseCRLF = strings.EqualFold(options.LineEnding, "CRLF")
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+		writer.Flush()
+		return writer.Error()
+	}
+
+	var headerBuf bytes.Buffer
+	if err := encode(&headerBuf, header); err != nil {
+		return nil, err
+	}
+
+	var jobs []*IngestJobInfo
+	upload := func(chunk *bytes.Buffer) error {
+		job, err := c.CreateIngestJob(options)
+		if err != nil {
+			return err
+		}
+		jobs = append(jobs, job)
+
+		err = c.UploadIngestJobData(job.ID, chunk)
+		if err == nil {
+			err = c.CloseIngestJob(job.ID)
+		}
+		if err != nil {
+			// Don't leave the job open until it times out
+			_ = c.AbortIngestJob(job.ID)
+			return err
+		}
+		return nil
+	}
+
+	var chunk, row bytes.Buffer
+	chunk.Write(headerBuf.Bytes())
+	rows := 0
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return jobs, err
+		}
+
+		row.Reset()
+		if err := encode(&row, record); err != nil {
+			return jobs, err
+		}
+		if headerBuf.Len()+row.Len() > maxUploadBytes {
+			return jobs, fmt.Errorf("CSV row of %d bytes exceeds the upload limit of %d bytes", row.Len(), maxUploadBytes)
+		}
+
+		if chunk.Len()+row.Len() > maxUploadBytes {
+			if err := upload(&chunk); err != nil {
+				return jobs, err
+			}
+			chunk.Reset()
+			chunk.Write(headerBuf.Bytes())
+			rows = 0
+		}
+
+		chunk.Write(row.Bytes())
+		rows++
+	}
+
+	if rows > 0 {
+		if err := upload(&chunk); err != nil {
+			return jobs, err
+		}
+	}
+
+	return jobs, nil
+}
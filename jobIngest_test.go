@@ -0,0 +1,186 @@
+package go_salesforce_api_client_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	go_salesforce_api_client "github.com/MASA-JAPAN/go-salesforce-api-client"
+)
+
+func TestCreateIngestJob(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("Failed to decode request: %s", err)
+		}
+		if payload["operation"] != "upsert" || payload["externalIdFieldName"] != "External_Id__c" {
+			t.Errorf("Unexpected payload: %v", payload)
+		}
+		if payload["columnDelimiter"] != "PIPE" {
+			t.Errorf("Expected PIPE delimiter, got %s", payload["columnDelimiter"])
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":"7505g00000XXXXXAAA","operation":"upsert","object":"Account","state":"Open","columnDelimiter":"PIPE"}`))
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	job, err := client.CreateIngestJob(go_salesforce_api_client.IngestJobOptions{
+		Object:              "Account",
+		Operation:           go_salesforce_api_client.IngestOperationUpsert,
+		ExternalIDFieldName: "External_Id__c",
+		ColumnDelimiter:     "PIPE",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if job.ID != "7505g00000XXXXXAAA" || job.State != "Open" {
+		t.Errorf("Unexpected job: %+v", job)
+	}
+}
+
+func TestCreateIngestJob_InvalidDelimiter(t *testing.T) {
+	t.Parallel()
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: "http://localhost"}
+	_, err := client.CreateIngestJob(go_salesforce_api_client.IngestJobOptions{
+		Object:          "Account",
+		Operation:       go_salesforce_api_client.IngestOperationInsert,
+		ColumnDelimiter: "COLON",
+	})
+	if err == nil {
+		t.Fatal("Expected error for unsupported delimiter")
+	}
+}
+
+func TestGetIngestJobFailedResults(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/data/v58.0/jobs/ingest/job_id/failedResults/" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, "\"sf__Id\"|\"sf__Error\"|Name\n\"\"|\"REQUIRED_FIELD_MISSING:Required fields are missing: [Name]:Name --\"|\"\"\n")
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	results, err := client.GetIngestJobFailedResults("job_id")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 result, got: %d", len(results))
+	}
+	if !strings.HasPrefix(results[0].Error(), "REQUIRED_FIELD_MISSING") {
+		t.Errorf("Unexpected error column: %s", results[0].Error())
+	}
+}
+
+func TestGetIngestJobSuccessfulResults_Empty(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	results, err := client.GetIngestJobSuccessfulResults("job_id")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("Expected no results, got: %d", len(results))
+	}
+}
+
+func TestIngestCSV_SplitsUploads(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	var uploads []string
+	closed := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"id":"job","state":"Open"}`))
+		case r.Method == http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			uploads = append(uploads, string(body))
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodPatch:
+			closed++
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	data := "Name,Description\nAcme,\"multi\nline\"\nGlobex,plain\nInitech,plain\n"
+	jobs, err := client.IngestCSV(go_salesforce_api_client.IngestJobOptions{
+		Object:    "Account",
+		Operation: go_salesforce_api_client.IngestOperationInsert,
+	}, strings.NewReader(data), 45)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(jobs) != 2 || len(uploads) != 2 || closed != 2 {
+		t.Fatalf("Expected 2 jobs, uploads and closes, got %d, %d, %d", len(jobs), len(uploads), closed)
+	}
+	for _, upload := range uploads {
+		if !strings.HasPrefix(upload, "Name,Description\n") {
+			t.Errorf("Expected header in every upload, got %q", upload)
+		}
+		if len(upload) > 45 {
+			t.Errorf("Upload of %d bytes exceeds the limit", len(upload))
+		}
+	}
+	if !strings.Contains(uploads[0], "\"multi\nline\"") {
+		t.Errorf("Expected quoted newline to be preserved, got %q", uploads[0])
+	}
+}
+
+func TestIngestCSV_AbortsJobOnUploadFailure(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	var states []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case http.MethodPost:
+			_, _ = w.Write([]byte(`{"id":"job","state":"Open"}`))
+		case http.MethodPut:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`[{"errorCode":"INVALIDJOBSTATE"}]`))
+		case http.MethodPatch:
+			var payload map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			states = append(states, payload["state"])
+		}
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	jobs, err := client.IngestCSV(go_salesforce_api_client.IngestJobOptions{
+		Object:    "Account",
+		Operation: go_salesforce_api_client.IngestOperationInsert,
+	}, strings.NewReader("Name\nAcme\n"), 0)
+	if err == nil || !strings.Contains(err.Error(), "INVALIDJOBSTATE") {
+		t.Fatalf("Expected the upload error, got: %v", err)
+	}
+	if len(jobs) != 1 {
+		t.Errorf("Expected the created job to be returned, got %d", len(jobs))
+	}
+	if strings.Join(states, ",") != "Aborted" {
+		t.Errorf("Expected the job to be aborted, got states %v", states)
+	}
+}
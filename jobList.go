@@ -0,0 +1,298 @@
+package go_salesforce_api_client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// JobListOptions filters the jobs returned by ListJobQueries and ListIngestJobs
+type JobListOptions struct {
+	IsPkChunkingEnabled *bool
+	JobType             string // BigObjectIngest, Classic, V2Query or V2Ingest
+	ConcurrencyMode     string // serial or parallel
+	QueryLocator        string // Locator of the next page from a previous call
+}
+
+// JobQueryList represents a page of Bulk Query Jobs
+type JobQueryList struct {
+	Done           bool               `json:"done"`
+	Records        []JobQueryResponse `json:"records"`
+	NextRecordsURL string             `json:"nextRecordsUrl"`
+}
+
+// IngestJobList represents a page of Bulk API 2.0 ingest jobs
+type IngestJobList struct {
+	Done           bool            `json:"done"`
+	Records        []IngestJobInfo `json:"records"`
+	NextRecordsURL string          `json:"nextRecordsUrl"`
+}
+
+// JobCleanupOptions configures CleanupJobs
+type JobCleanupOptions struct {
+	OlderThan   time.Duration // Only jobs created before now minus OlderThan are cleaned up, must be positive
+	AnyAge      bool          // Allow a zero OlderThan, cleaning up every job including running ones
+	CreatedByID string        // Defaults to the authenticated user
+	DryRun      bool          // Report the jobs that would be cleaned up without changing them
+}
+
+// JobCleanupResult describes what CleanupJobs did with a single job
+type JobCleanupResult struct {
+	JobID       string
+	Kind        string // query or ingest
+	State       string
+	CreatedDate time.Time
+	Aborted     bool
+	Deleted     bool
+	Err         error
+}
+
+// salesforceTimeLayout is the timestamp format used by the REST API
+const salesforceTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// parseSalesforceTime parses a REST API timestamp such as 2024-01-02T03:04:05.000+0000
+func parseSalesforceTime(value string) (time.Time, error) {
+	if t, err := time.Parse(salesforceTimeLayout, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// NextQueryLocator returns the locator of the next page, or an empty string on the last page
+func (l *JobQueryList) NextQueryLocator() string {
+	return queryLocatorFromURL(l.Done, l.NextRecordsURL)
+}
+
+// NextQueryLocator returns the locator of the next page, or an empty string on the last page
+func (l *IngestJobList) NextQueryLocator() string {
+	return queryLocatorFromURL(l.Done, l.NextRecordsURL)
+}
+
+// queryLocatorFromURL extracts the queryLocator parameter of a nextRecordsUrl
+func queryLocatorFromURL(done bool, nextRecordsURL string) string {
+	if done || nextRecordsURL == "" {
+		return ""
+	}
+	parsed, err := url.Parse(nextRecordsURL)
+	if err != nil {
+		return ""
+	}
+	return parsed.Query().Get("queryLocator")
+}
+
+// encode converts the options into query parameters
+func (o JobListOptions) encode() string {
+	params := url.Values{}
+	if o.IsPkChunkingEnabled != nil {
+		params.Set("isPkChunkingEnabled", strconv.FormatBool(*o.IsPkChunkingEnabled))
+	}
+	if o.JobType != "" {
+		params.Set("jobType", o.JobType)
+	}
+	if o.ConcurrencyMode != "" {
+		params.Set("concurrencyMode", o.ConcurrencyMode)
+	}
+	if o.QueryLocator != "" {
+		params.Set("queryLocator", o.QueryLocator)
+	}
+	if len(params) == 0 {
+		return ""
+	}
+	return "?" + params.Encode()
+}
+
+// ListJobQueries retrieves a page of Bulk Query Jobs in the org
+func (c *Client) ListJobQueries(options JobListOptions) (*JobQueryList, error) {
+	var list JobQueryList
+	if err := c.listJobs("query", options, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// ListIngestJobs retrieves a page of Bulk API 2.0 ingest jobs in the org
+func (c *Client) ListIngestJobs(options JobListOptions) (*IngestJobList, error) {
+	var list IngestJobList
+	if err := c.listJobs("ingest", options, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// listJobs retrieves a page of jobs of the given kind and decodes it into list
+func (c *Client) listJobs(kind string, options JobListOptions, list any) error {
+	if c.AccessToken == "" || c.InstanceURL == "" {
+		return errors.New("missing authentication details")
+	}
+
+	listURL := fmt.Sprintf("%s/services/data/v58.0/jobs/%s%s", c.InstanceURL, kind, options.encode())
+
+	req, err := http.NewRequest(http.MethodGet, listURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.AccessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to list %s jobs, status: %d, response: %s", kind, resp.StatusCode, string(body))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(body, list)
+}
+
+// currentUserID returns the ID of the authenticated user
+func (c *Client) currentUserID() (string, error) {
+	if c.AccessToken == "" || c.InstanceURL == "" {
+		return "", errors.New("missing authentication details")
+	}
+
+	url := fmt.Sprintf("%s/services/oauth2/userinfo", c.InstanceURL)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.AccessToken)
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("failed to retrieve user info, status: %d, response: %s", resp.StatusCode, string(body))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	var userInfo struct {
+		UserID string `json:"user_id"`
+	}
+	if err := json.Unmarshal(body, &userInfo); err != nil {
+		return "", err
+	}
+
+	return userInfo.UserID, nil
+}
+
+// isTerminalJobState reports whether a job has finished and can be deleted
+func isTerminalJobState(state string) bool {
+	return state == JobStateJobComplete || state == JobStateFailed || state == JobStateAborted
+}
+
+// CleanupJobs aborts and deletes Bulk API 2.0 query and ingest jobs created
+// by a user before the given age. Jobs that are still running are aborted
+// first. Failures are reported per job in the results; an error is only
+// returned when the options are invalid or the jobs cannot be listed.
+func (c *Client) CleanupJobs(options JobCleanupOptions) ([]JobCleanupResult, error) {
+	if options.OlderThan <= 0 && !options.AnyAge {
+		return nil, errors.New("OlderThan must be positive unless AnyAge is set")
+	}
+
+	createdByID := options.CreatedByID
+	if createdByID == "" {
+		userID, err := c.currentUserID()
+		if err != nil {
+			return nil, err
+		}
+		createdByID = userID
+	}
+
+	cutoff := time.Now().Add(-options.OlderThan)
+
+	// Collect candidates first so deletions don't disturb pagination
+	var candidates []JobCleanupResult
+	collect := func(kind, jobType, id, state, createdBy, createdDate string) {
+		// Bulk API 1.0 and big object jobs can't be aborted or deleted through 2.0
+		if jobType != "" && jobType != "V2Query" && jobType != "V2Ingest" {
+			return
+		}
+		if createdBy != createdByID {
+			return
+		}
+		created, err := parseSalesforceTime(createdDate)
+		if err != nil || !created.Before(cutoff) {
+			return
+		}
+		candidates = append(candidates, JobCleanupResult{JobID: id, Kind: kind, State: state, CreatedDate: created})
+	}
+
+	queryOptions := JobListOptions{JobType: "V2Query"}
+	for {
+		list, err := c.ListJobQueries(queryOptions)
+		if err != nil {
+			return nil, err
+		}
+		for _, job := range list.Records {
+			collect("query", job.JobType, job.ID, job.State, job.CreatedByID, job.CreatedDate)
+		}
+		if queryOptions.QueryLocator = list.NextQueryLocator(); queryOptions.QueryLocator == "" {
+			break
+		}
+	}
+
+	ingestOptions := JobListOptions{JobType: "V2Ingest"}
+	for {
+		list, err := c.ListIngestJobs(ingestOptions)
+		if err != nil {
+			return nil, err
+		}
+		for _, job := range list.Records {
+			collect("ingest", job.JobType, job.ID, job.State, job.CreatedByID, job.CreatedDate)
+		}
+		if ingestOptions.QueryLocator = list.NextQueryLocator(); ingestOptions.QueryLocator == "" {
+			break
+		}
+	}
+
+	if options.DryRun {
+		return candidates, nil
+	}
+
+	for i := range candidates {
+		job := &candidates[i]
+
+		abort, remove := c.AbortJobQuery, c.DeleteJobQuery
+		if job.Kind == "ingest" {
+			abort, remove = c.AbortIngestJob, c.DeleteIngestJob
+		}
+
+		if !isTerminalJobState(job.State) {
+			if job.Err = abort(job.JobID); job.Err != nil {
+				continue
+			}
+			job.Aborted = true
+		}
+
+		if job.Err = remove(job.JobID); job.Err == nil {
+			job.Deleted = true
+		}
+	}
+
+	return candidates, nil
+}
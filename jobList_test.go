@@ -0,0 +1,149 @@
+package go_salesforce_api_client_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	go_salesforce_api_client "github.com/MASA-JAPAN/go-salesforce-api-client"
+)
+
+func TestListJobQueries(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("isPkChunkingEnabled") != "false" || query.Get("jobType") != "V2Query" {
+			t.Errorf("Unexpected query parameters: %s", r.URL.RawQuery)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{"done":false,"records":[{"id":"750A","state":"JobComplete","createdById":"005X"}],"nextRecordsUrl":"/services/data/v58.0/jobs/query?queryLocator=01gRM000000Tdos"}`)
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	disabled := false
+	list, err := client.ListJobQueries(go_salesforce_api_client.JobListOptions{
+		IsPkChunkingEnabled: &disabled,
+		JobType:             "V2Query",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(list.Records) != 1 || list.Records[0].ID != "750A" {
+		t.Errorf("Unexpected records: %+v", list.Records)
+	}
+	if list.NextQueryLocator() != "01gRM000000Tdos" {
+		t.Errorf("Unexpected locator: %s", list.NextQueryLocator())
+	}
+}
+
+func TestCleanupJobs(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	var actions []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.URL.Path == "/services/oauth2/userinfo":
+			_, _ = io.WriteString(w, `{"user_id":"005ME"}`)
+		case r.URL.Path == "/services/data/v58.0/jobs/query" && r.URL.Query().Get("jobType") != "V2Query":
+			t.Errorf("Expected only V2Query jobs to be listed: %s", r.URL.RawQuery)
+		case r.URL.Path == "/services/data/v58.0/jobs/query" && r.URL.Query().Get("queryLocator") == "":
+			_, _ = io.WriteString(w, `{"done":false,"nextRecordsUrl":"/services/data/v58.0/jobs/query?queryLocator=next","records":[
+				{"id":"750OLD","state":"InProgress","createdById":"005ME","createdDate":"2020-01-01T00:00:00.000+0000"},
+				{"id":"750OTHER","state":"JobComplete","createdById":"005OTHER","createdDate":"2020-01-01T00:00:00.000+0000"}
+			]}`)
+		case r.URL.Path == "/services/data/v58.0/jobs/query":
+			_, _ = io.WriteString(w, `{"done":true,"records":[
+				{"id":"750NEW","state":"JobComplete","createdById":"005ME","createdDate":"`+time.Now().UTC().Format("2006-01-02T15:04:05.000-0700")+`"}
+			]}`)
+		case r.URL.Path == "/services/data/v58.0/jobs/ingest":
+			if r.URL.Query().Get("jobType") != "V2Ingest" {
+				t.Errorf("Expected only V2Ingest jobs to be listed: %s", r.URL.RawQuery)
+			}
+			_, _ = io.WriteString(w, `{"done":true,"records":[
+				{"id":"750ING","state":"JobComplete","jobType":"V2Ingest","createdById":"005ME","createdDate":"2020-01-01T00:00:00.000+0000"},
+				{"id":"750CLASSIC","state":"Closed","jobType":"Classic","createdById":"005ME","createdDate":"2020-01-01T00:00:00.000+0000"}
+			]}`)
+		case r.Method == http.MethodPatch:
+			actions = append(actions, "abort "+r.URL.Path)
+		case r.Method == http.MethodDelete:
+			actions = append(actions, "delete "+r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	results, err := client.CleanupJobs(go_salesforce_api_client.JobCleanupOptions{OlderThan: 24 * time.Hour})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 cleaned up jobs, got: %+v", results)
+	}
+	for _, result := range results {
+		if result.Err != nil || !result.Deleted {
+			t.Errorf("Expected %s to be deleted, got: %+v", result.JobID, result)
+		}
+	}
+	if !results[0].Aborted || results[1].Aborted {
+		t.Errorf("Expected only the running job to be aborted: %+v", results)
+	}
+
+	sort.Strings(actions)
+	expected := []string{
+		"abort /services/data/v58.0/jobs/query/750OLD",
+		"delete /services/data/v58.0/jobs/ingest/750ING",
+		"delete /services/data/v58.0/jobs/query/750OLD",
+	}
+	if strings.Join(actions, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Unexpected actions: %v", actions)
+	}
+}
+
+func TestCleanupJobs_DryRun(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Unexpected %s request during dry run", r.Method)
+		}
+		_, _ = io.WriteString(w, `{"done":true,"records":[{"id":"750OLD","state":"Open","createdById":"005ME","createdDate":"2020-01-01T00:00:00.000+0000"}]}`)
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	results, err := client.CleanupJobs(go_salesforce_api_client.JobCleanupOptions{OlderThan: time.Hour, CreatedByID: "005ME", DryRun: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(results) != 2 || results[0].Deleted || results[1].Deleted {
+		t.Errorf("Unexpected results: %+v", results)
+	}
+}
+
+func TestCleanupJobs_RequiresOlderThan(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected %s request to %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	results, err := client.CleanupJobs(go_salesforce_api_client.JobCleanupOptions{CreatedByID: "005ME"})
+	if err == nil {
+		t.Fatal("Expected an error for a zero OlderThan")
+	}
+	if len(results) != 0 {
+		t.Errorf("Expected no results, got: %+v", results)
+	}
+}
@@ -1,38 +1,157 @@
 package go_salesforce_api_client
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 )
 
 // JobQueryResponse represents the response from Salesforce Bulk Query API
 type JobQueryResponse struct {
-	ID     string `json:"id"`
-	State  string `json:"state"`
-	Object string `json:"object"`
+	ID                     string  `json:"id"`
+	State                  string  `json:"state"`
+	Object                 string  `json:"object"`
+	Operation              string  `json:"operation"`
+	CreatedByID            string  `json:"createdById"`
+	CreatedDate            string  `json:"createdDate"`
+	SystemModstamp         string  `json:"systemModstamp"`
+	ConcurrencyMode        string  `json:"concurrencyMode"`
+	ContentType            string  `json:"contentType"`
+	APIVersion             float64 `json:"apiVersion"`
+	JobType                string  `json:"jobType"`
+	LineEnding             string  `json:"lineEnding"`
+	ColumnDelimiter        string  `json:"columnDelimiter"`
+	NumberRecordsProcessed int     `json:"numberRecordsProcessed"`
+	Retries                int     `json:"retries"`
+	TotalProcessingTime    int64   `json:"totalProcessingTime"`
+	IsPkChunkingSupported  bool    `json:"isPkChunkingSupported"`
+	ErrorMessage           string  `json:"errorMessage"`
+}
+
+// Bulk API 2.0 job states
+const (
+	JobStateOpen           = "Open"
+	JobStateUploadComplete = "UploadComplete"
+	JobStateInProgress     = "InProgress"
+	JobStateJobComplete    = "JobComplete"
+	JobStateFailed         = "Failed"
+	JobStateAborted        = "Aborted"
+)
+
+// Bulk job terminal state errors
+var (
+	ErrJobFailed  = errors.New("bulk job failed")
+	ErrJobAborted = errors.New("bulk job aborted")
+)
+
+// JobStateError is returned when a bulk job ends in the Failed or Aborted state.
+// It matches ErrJobFailed or ErrJobAborted with errors.Is.
+type JobStateError struct {
+	JobID        string
+	State        string
+	ErrorMessage string
+}
+
+func (e *JobStateError) Error() string {
+	if e.ErrorMessage != "" {
+		return fmt.Sprintf("bulk job %s ended in state %s: %s", e.JobID, e.State, e.ErrorMessage)
+	}
+	return fmt.Sprintf("bulk job %s ended in state %s", e.JobID, e.State)
+}
+
+// Unwrap returns the sentinel error matching the terminal state
+func (e *JobStateError) Unwrap() error {
+	if e.State == JobStateAborted {
+		return ErrJobAborted
+	}
+	return ErrJobFailed
+}
+
+// JobQueryWaitOptions configures WaitForJobQuery
+type JobQueryWaitOptions struct {
+	WaitOptions
+	OnProgress func(job *JobQueryResponse) // Called after every poll
 }
 
 // JobQueryResult represents a single row in the job query results.
 type JobQueryResult map[string]string
 
+// Bulk query operations
+const (
+	JobQueryOperationQuery    = "query"
+	JobQueryOperationQueryAll = "queryAll" // Includes deleted and archived records
+)
+
+// Bulk API column delimiters
+const (
+	ColumnDelimiterComma     = "COMMA"
+	ColumnDelimiterTab       = "TAB"
+	ColumnDelimiterPipe      = "PIPE"
+	ColumnDelimiterSemicolon = "SEMICOLON"
+	ColumnDelimiterCaret     = "CARET"
+	ColumnDelimiterBackquote = "BACKQUOTE"
+)
+
+// Bulk API line endings
+const (
+	LineEndingLF   = "LF"
+	LineEndingCRLF = "CRLF"
+)
+
+// JobQueryOptions configures a Bulk Query Job
+type JobQueryOptions struct {
+	Operation       string // query (default) or queryAll
+	ColumnDelimiter string // COMMA (default), TAB, PIPE, SEMICOLON, CARET, BACKQUOTE
+	LineEnding      string // LF (default) or CRLF
+}
+
 // CreateJobQuery initiates a Bulk Query Job in Salesforce
 func (c *Client) CreateJobQuery(query string) (*JobQueryResponse, error) {
+	return c.CreateJobQueryWithOptions(query, JobQueryOptions{})
+}
+
+// CreateJobQueryWithOptions initiates a Bulk Query Job with the given operation, delimiter and line ending
+func (c *Client) CreateJobQueryWithOptions(query string, options JobQueryOptions) (*JobQueryResponse, error) {
 	if c.AccessToken == "" || c.InstanceURL == "" {
 		return nil, errors.New("missing authentication details")
 	}
 
+	operation := options.Operation
+	if operation == "" {
+		operation = JobQueryOperationQuery
+	}
+	if operation != JobQueryOperationQuery && operation != JobQueryOperationQueryAll {
+		return nil, fmt.Errorf("unsupported query operation %q", operation)
+	}
+	if _, err := columnDelimiterRune(options.ColumnDelimiter); err != nil {
+		return nil, err
+	}
+	if options.LineEnding != "" && options.LineEnding != LineEndingLF && options.LineEnding != LineEndingCRLF {
+		return nil, fmt.Errorf("unsupported line ending %q", options.LineEnding)
+	}
+
 	url := fmt.Sprintf("%s/services/data/v58.0/jobs/query", c.InstanceURL)
 
 	requestBody := map[string]interface{}{
-		"operation":   "query",
+		"operation":   operation,
 		"query":       query,
 		"contentType": "CSV",
 	}
+	if options.ColumnDelimiter != "" {
+		requestBody["columnDelimiter"] = strings.ToUpper(options.ColumnDelimiter)
+	}
+	if options.LineEnding != "" {
+		requestBody["lineEnding"] = options.LineEnding
+	}
 
 	jsonData, err := json.Marshal(requestBody)
 	if err != nil {
@@ -155,7 +274,10 @@ func (c *Client) GetJobQueryResults(jobID, queryLocator string, maxRecords int)
 	return responseData, nextLocator, nil
 }
 
-// GetJobQueryResultsParsed retrieves job query results and converts them into a structured format
+// GetJobQueryResultsParsed retrieves job query results and converts them into a
+// structured format. The results don't state their column delimiter, so it is
+// detected from the first delimiter character in the header line; a header
+// without one is read as a single column.
 func (c *Client) GetJobQueryResultsParsed(jobID, queryLocator string, maxRecords int) ([]JobQueryResult, string, error) {
 	if c.AccessToken == "" || c.InstanceURL == "" {
 		return nil, "", errors.New("missing authentication details")
@@ -185,8 +307,8 @@ func (c *Client) GetJobQueryResultsParsed(jobID, queryLocator string, maxRecords
 		return nil, "", fmt.Errorf("failed to retrieve job query results, status: %d, response: %s", resp.StatusCode, string(body))
 	}
 
-	// Parse CSV response
-	reader := csv.NewReader(resp.Body)
+	// Parse CSV response using the delimiter detected from the header line
+	reader := newBulkCSVReader(resp.Body)
 	records, err := reader.ReadAll()
 	if err != nil {
 		return nil, "", err
@@ -282,3 +404,230 @@ func (c *Client) DeleteJobQuery(jobID string) error {
 
 	return nil
 }
+
+// WaitForJobQuery polls a Bulk Query Job until it reaches JobComplete, Failed
+// or Aborted. A *JobStateError is returned together with the last job details
+// when the job does not complete successfully.
+func (c *Client) WaitForJobQuery(ctx context.Context, jobID string, options JobQueryWaitOptions) (*JobQueryResponse, error) {
+	var job *JobQueryResponse
+	err := poll(ctx, options.WaitOptions, func() (bool, error) {
+		var err error
+		job, err = c.GetJobQuery(jobID)
+		if err != nil {
+			return false, err
+		}
+
+		if options.OnProgress != nil {
+			options.OnProgress(job)
+		}
+
+		switch job.State {
+		case JobStateJobComplete:
+			return true, nil
+		case JobStateFailed, JobStateAborted:
+			return true, &JobStateError{JobID: jobID, State: job.State, ErrorMessage: job.ErrorMessage}
+		}
+		return false, nil
+	})
+
+	return job, err
+}
+
+// openJobQueryResults requests a page of job query results and returns the
+// response with its body unread. maxRecords is omitted when not positive.
+func (c *Client) openJobQueryResults(jobID, queryLocator string, maxRecords int) (*http.Response, error) {
+	if c.AccessToken == "" || c.InstanceURL == "" {
+		return nil, errors.New("missing authentication details")
+	}
+
+	params := url.Values{}
+	if maxRecords > 0 {
+		params.Set("maxRecords", strconv.Itoa(maxRecords))
+	}
+	if queryLocator != "" {
+		params.Set("locator", queryLocator)
+	}
+
+	resultsURL := fmt.Sprintf("%s/services/data/v58.0/jobs/query/%s/results", c.InstanceURL, jobID)
+	if len(params) > 0 {
+		resultsURL += "?" + params.Encode()
+	}
+
+	req, err := http.NewRequest(http.MethodGet, resultsURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.AccessToken)
+	req.Header.Set("Accept", "text/csv")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to retrieve job query results, status: %d, response: %s", resp.StatusCode, string(body))
+	}
+
+	return resp, nil
+}
+
+// nextJobQueryLocator returns the locator of the next page, or an empty
+// string when Salesforce reports the last page with the literal "null"
+func nextJobQueryLocator(resp *http.Response) string {
+	locator := resp.Header.Get("Sforce-Locator")
+	if locator == "null" {
+		return ""
+	}
+	return locator
+}
+
+// StreamJobQueryResults follows the Sforce-Locator of every result page and
+// writes the raw CSV to w. The header row is written only once.
+func (c *Client) StreamJobQueryResults(jobID string, maxRecords int, w io.Writer) error {
+	locator := ""
+	first := true
+	for {
+		resp, err := c.openJobQueryResults(jobID, locator, maxRecords)
+		if err != nil {
+			return err
+		}
+
+		err = copyJobQueryPage(w, resp.Body, !first)
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		first = false
+		locator = nextJobQueryLocator(resp)
+		if locator == "" {
+			return nil
+		}
+	}
+}
+
+// copyJobQueryPage copies a CSV page to w, optionally dropping its header row
+func copyJobQueryPage(w io.Writer, page io.Reader, skipHeader bool) error {
+	if skipHeader {
+		br := bufio.NewReader(page)
+		if _, err := br.ReadString('\n'); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		page = br
+	}
+
+	_, err := io.Copy(w, page)
+	return err
+}
+
+// JobQueryResultIterator reads Bulk Query results one row at a time,
+// fetching the next page only when the current one is exhausted
+type JobQueryResultIterator struct {
+	client     *Client
+	jobID      string
+	maxRecords int
+	locator    string
+	started    bool
+	body       io.ReadCloser
+	reader     *csv.Reader
+	headers    []string
+	row        JobQueryResult
+	err        error
+}
+
+// NewJobQueryResultIterator creates an iterator over all result pages of a job.
+// The iterator must be closed when it is not read to the end.
+func (c *Client) NewJobQueryResultIterator(jobID string, maxRecords int) *JobQueryResultIterator {
+	return &JobQueryResultIterator{
+		client:     c,
+		jobID:      jobID,
+		maxRecords: maxRecords,
+	}
+}
+
+// Next advances to the next row. It returns false when all pages have been
+// read or an error occurred; check Err afterwards.
+func (it *JobQueryResultIterator) Next() bool {
+	for it.err == nil {
+		if it.reader == nil {
+			if it.started && it.locator == "" {
+				return false
+			}
+			if !it.openPage() {
+				return false
+			}
+		}
+
+		record, err := it.reader.Read()
+		if errors.Is(err, io.EOF) {
+			it.closePage()
+			continue
+		}
+		if err != nil {
+			it.err = err
+			it.closePage()
+			return false
+		}
+
+		if it.headers == nil {
+			it.headers = append([]string(nil), record...)
+			continue
+		}
+
+		it.row = make(JobQueryResult, len(it.headers))
+		for i, value := range record {
+			it.row[it.headers[i]] = value
+		}
+		return true
+	}
+	return false
+}
+
+// openPage requests the next result page
+func (it *JobQueryResultIterator) openPage() bool {
+	resp, err := it.client.openJobQueryResults(it.jobID, it.locator, it.maxRecords)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	it.started = true
+	it.locator = nextJobQueryLocator(resp)
+	it.body = resp.Body
+	it.reader = newBulkCSVReader(resp.Body)
+	it.reader.ReuseRecord = true
+	it.headers = nil
+	return true
+}
+
+// closePage releases the current result page
+func (it *JobQueryResultIterator) closePage() {
+	if it.body != nil {
+		it.body.Close()
+	}
+	it.body = nil
+	it.reader = nil
+}
+
+// Row returns the current row
+func (it *JobQueryResultIterator) Row() JobQueryResult {
+	return it.row
+}
+
+// Err returns the first error encountered by the iterator
+func (it *JobQueryResultIterator) Err() error {
+	return it.err
+}
+
+// Close releases the page currently being read
+func (it *JobQueryResultIterator) Close() error {
+	it.closePage()
+	return nil
+}
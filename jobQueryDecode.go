@@ -0,0 +1,278 @@
+package go_salesforce_api_client
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JobQueryDecoder decodes Bulk query rows into structs or typed maps.
+//
+// Struct fields are matched to CSV headers by their `csv` tag, or by field
+// name when there is no tag; `csv:"-"` skips a field. Nested struct fields
+// match dotted relationship headers, so a field `Account AccountInfo` with
+// `csv:"Account"` is filled from headers like "Account.Name". Empty values
+// are treated as null: pointer fields stay nil and other fields keep their
+// zero value. Describe metadata, when provided, is used to convert values
+// of interface{} fields and of DecodeMap results to their Salesforce types.
+type JobQueryDecoder struct {
+	fieldTypes map[string]string
+}
+
+// jobQueryTimeType is the reflect type of time.Time
+var jobQueryTimeType = reflect.TypeOf(time.Time{})
+
+// NewJobQueryDecoder creates a decoder using the result of DescribeSObject
+// for the queried object. describe may be nil.
+func NewJobQueryDecoder(describe map[string]interface{}) *JobQueryDecoder {
+	d := &JobQueryDecoder{fieldTypes: make(map[string]string)}
+	d.addDescribe("", describe)
+	return d
+}
+
+// WithRelationship adds describe metadata for a relationship such as "Account",
+// so headers like "Account.AnnualRevenue" are converted too
+func (d *JobQueryDecoder) WithRelationship(relationship string, describe map[string]interface{}) *JobQueryDecoder {
+	d.addDescribe(relationship+".", describe)
+	return d
+}
+
+// addDescribe records the field types of a describe result under a header prefix
+func (d *JobQueryDecoder) addDescribe(prefix string, describe map[string]interface{}) {
+	fields, _ := describe["fields"].([]interface{})
+	for _, f := range fields {
+		field, ok := f.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := field["name"].(string)
+		fieldType, _ := field["type"].(string)
+		if name != "" && fieldType != "" {
+			d.fieldTypes[prefix+name] = fieldType
+		}
+	}
+}
+
+// UnmarshalJobQueryResult decodes a single row into the struct pointed to by v
+// without describe metadata
+func UnmarshalJobQueryResult(row JobQueryResult, v any) error {
+	return NewJobQueryDecoder(nil).Decode(row, v)
+}
+
+// DecodeJobQueryResults decodes rows into a slice of T. decoder may be nil.
+func DecodeJobQueryResults[T any](rows []JobQueryResult, decoder *JobQueryDecoder) ([]T, error) {
+	if decoder == nil {
+		decoder = NewJobQueryDecoder(nil)
+	}
+
+	results := make([]T, len(rows))
+	for i, row := range rows {
+		if err := decoder.Decode(row, &results[i]); err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+1, err)
+		}
+	}
+	return results, nil
+}
+
+// Decode decodes a single row into the struct pointed to by v
+func (d *JobQueryDecoder) Decode(row JobQueryResult, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("decode target must be a non-nil pointer to a struct, got %T", v)
+	}
+	return d.decodeStruct(row, "", rv.Elem())
+}
+
+// DecodeMap converts a row into a map with values typed according to the
+// describe metadata. Empty values become nil; fields without metadata stay strings.
+func (d *JobQueryDecoder) DecodeMap(row JobQueryResult) (map[string]any, error) {
+	result := make(map[string]any, len(row))
+	for header, value := range row {
+		converted, err := d.convertByDescribe(header, value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", header, err)
+		}
+		result[header] = converted
+	}
+	return result, nil
+}
+
+// decodeStruct fills the fields of a struct from headers starting with prefix
+func (d *JobQueryDecoder) decodeStruct(row JobQueryResult, prefix string, rv reflect.Value) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := field.Name
+		if tag, ok := field.Tag.Lookup("csv"); ok {
+			if tag == "-" {
+				continue
+			}
+			if tag != "" {
+				name = tag
+			}
+		}
+		header := prefix + name
+		fv := rv.Field(i)
+
+		if isNestedJobQueryStruct(field.Type) {
+			if err := d.decodeNested(row, header+".", fv); err != nil {
+				return err
+			}
+			continue
+		}
+
+		value, ok := row[header]
+		if !ok || value == "" {
+			continue
+		}
+		if err := d.setValue(header, value, fv); err != nil {
+			return fmt.Errorf("%s: %w", header, err)
+		}
+	}
+	return nil
+}
+
+// isNestedJobQueryStruct reports whether a field maps to a relationship
+func isNestedJobQueryStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == jobQueryTimeType {
+		return false
+	}
+	return !reflect.PointerTo(t).Implements(reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem())
+}
+
+// decodeNested fills a relationship struct; pointers stay nil when all of its columns are empty
+func (d *JobQueryDecoder) decodeNested(row JobQueryResult, prefix string, fv reflect.Value) error {
+	if fv.Kind() != reflect.Pointer {
+		return d.decodeStruct(row, prefix, fv)
+	}
+
+	hasValue := false
+	for header, value := range row {
+		if value != "" && strings.HasPrefix(header, prefix) {
+			hasValue = true
+			break
+		}
+	}
+	if !hasValue {
+		return nil
+	}
+
+	target := reflect.New(fv.Type().Elem())
+	if err := d.decodeStruct(row, prefix, target.Elem()); err != nil {
+		return err
+	}
+	fv.Set(target)
+	return nil
+}
+
+// setValue converts a non-empty CSV value into the type of fv
+func (d *JobQueryDecoder) setValue(header, value string, fv reflect.Value) error {
+	if fv.Kind() == reflect.Pointer {
+		target := reflect.New(fv.Type().Elem())
+		if err := d.setValue(header, value, target.Elem()); err != nil {
+			return err
+		}
+		fv.Set(target)
+		return nil
+	}
+
+	if fv.Type() == jobQueryTimeType {
+		t, err := parseJobQueryTime(value)
+		if err != nil {
+			return err
+		}
+		fv.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	if unmarshaler, ok := fv.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return unmarshaler.UnmarshalText([]byte(value))
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		// Integers may be returned with a decimal part, e.g. NumberOfEmployees "12.0"
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		if f != float64(int64(f)) || fv.OverflowInt(int64(f)) {
+			return fmt.Errorf("value %s does not fit in %s", value, fv.Type())
+		}
+		fv.SetInt(int64(f))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		if f < 0 || f != float64(uint64(f)) || fv.OverflowUint(uint64(f)) {
+			return fmt.Errorf("value %s does not fit in %s", value, fv.Type())
+		}
+		fv.SetUint(uint64(f))
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(f)
+	case reflect.Interface:
+		converted, err := d.convertByDescribe(header, value)
+		if err != nil {
+			return err
+		}
+		fv.Set(reflect.ValueOf(converted))
+	default:
+		return fmt.Errorf("unsupported field type %s", fv.Type())
+	}
+	return nil
+}
+
+// convertByDescribe converts a value according to the describe type of its header
+func (d *JobQueryDecoder) convertByDescribe(header, value string) (any, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	switch d.fieldTypes[header] {
+	case "boolean":
+		return strconv.ParseBool(value)
+	case "int":
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, err
+		}
+		return int64(f), nil
+	case "double", "currency", "percent":
+		return strconv.ParseFloat(value, 64)
+	case "date", "datetime":
+		return parseJobQueryTime(value)
+	default:
+		return value, nil
+	}
+}
+
+// parseJobQueryTime parses the date and datetime formats used in Bulk query results
+func parseJobQueryTime(value string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	return parseSalesforceTime(value)
+}
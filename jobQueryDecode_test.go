@@ -0,0 +1,126 @@
+package go_salesforce_api_client_test
+
+import (
+	"testing"
+	"time"
+
+	go_salesforce_api_client "github.com/MASA-JAPAN/go-salesforce-api-client"
+)
+
+type decodedOwner struct {
+	Name  string
+	Email *string
+}
+
+type decodedAccount struct {
+	ID                string    `csv:"Id"`
+	Name              string    `csv:"Name"`
+	NumberOfEmployees int       `csv:"NumberOfEmployees"`
+	AnnualRevenue     *float64  `csv:"AnnualRevenue"`
+	IsActive          bool      `csv:"IsActive__c"`
+	CreatedDate       time.Time `csv:"CreatedDate"`
+	LastActivityDate  *time.Time
+	Rating            any
+	Owner             decodedOwner  `csv:"Owner"`
+	Parent            *decodedOwner `csv:"Parent"`
+	Ignored           string        `csv:"-"`
+}
+
+func TestDecodeJobQueryResults(t *testing.T) {
+	t.Parallel()
+	rows := []go_salesforce_api_client.JobQueryResult{
+		{
+			"Id":                "001A",
+			"Name":              "Acme",
+			"NumberOfEmployees": "250.0",
+			"AnnualRevenue":     "",
+			"IsActive__c":       "true",
+			"CreatedDate":       "2024-03-01T10:15:30.000Z",
+			"LastActivityDate":  "2024-03-05",
+			"Rating":            "Hot",
+			"Owner.Name":        "Jane Doe",
+			"Owner.Email":       "",
+			"Parent.Name":       "",
+			"Parent.Email":      "",
+			"Ignored":           "value",
+		},
+	}
+
+	accounts, err := go_salesforce_api_client.DecodeJobQueryResults[decodedAccount](rows, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	account := accounts[0]
+	if account.ID != "001A" || account.NumberOfEmployees != 250 || !account.IsActive {
+		t.Errorf("Unexpected scalar fields: %+v", account)
+	}
+	if account.AnnualRevenue != nil {
+		t.Errorf("Expected nil AnnualRevenue, got %v", *account.AnnualRevenue)
+	}
+	if !account.CreatedDate.Equal(time.Date(2024, 3, 1, 10, 15, 30, 0, time.UTC)) {
+		t.Errorf("Unexpected CreatedDate: %v", account.CreatedDate)
+	}
+	if account.LastActivityDate == nil || account.LastActivityDate.Day() != 5 {
+		t.Errorf("Unexpected LastActivityDate: %v", account.LastActivityDate)
+	}
+	if account.Rating != "Hot" {
+		t.Errorf("Unexpected Rating: %v", account.Rating)
+	}
+	if account.Owner.Name != "Jane Doe" || account.Owner.Email != nil {
+		t.Errorf("Unexpected Owner: %+v", account.Owner)
+	}
+	if account.Parent != nil {
+		t.Errorf("Expected nil Parent, got %+v", account.Parent)
+	}
+	if account.Ignored != "" {
+		t.Errorf("Expected Ignored to be skipped, got %s", account.Ignored)
+	}
+}
+
+func TestJobQueryDecoder_Describe(t *testing.T) {
+	t.Parallel()
+	describe := map[string]interface{}{
+		"fields": []interface{}{
+			map[string]interface{}{"name": "IsDeleted", "type": "boolean"},
+			map[string]interface{}{"name": "AnnualRevenue", "type": "currency"},
+			map[string]interface{}{"name": "NumberOfEmployees", "type": "int"},
+		},
+	}
+	ownerDescribe := map[string]interface{}{
+		"fields": []interface{}{
+			map[string]interface{}{"name": "CreatedDate", "type": "datetime"},
+		},
+	}
+
+	decoder := go_salesforce_api_client.NewJobQueryDecoder(describe).WithRelationship("Owner", ownerDescribe)
+	row, err := decoder.DecodeMap(go_salesforce_api_client.JobQueryResult{
+		"IsDeleted":         "false",
+		"AnnualRevenue":     "1500000.5",
+		"NumberOfEmployees": "",
+		"Name":              "Acme",
+		"Owner.CreatedDate": "2024-03-01T10:15:30.000+0000",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if row["IsDeleted"] != false || row["AnnualRevenue"] != 1500000.5 || row["Name"] != "Acme" {
+		t.Errorf("Unexpected values: %v", row)
+	}
+	if row["NumberOfEmployees"] != nil {
+		t.Errorf("Expected nil for empty value, got %v", row["NumberOfEmployees"])
+	}
+	if _, ok := row["Owner.CreatedDate"].(time.Time); !ok {
+		t.Errorf("Expected time.Time for Owner.CreatedDate, got %T", row["Owner.CreatedDate"])
+	}
+}
+
+func TestUnmarshalJobQueryResult_InvalidValue(t *testing.T) {
+	t.Parallel()
+	var account decodedAccount
+	err := go_salesforce_api_client.UnmarshalJobQueryResult(go_salesforce_api_client.JobQueryResult{"NumberOfEmployees": "many"}, &account)
+	if err == nil {
+		t.Fatal("Expected error for invalid number")
+	}
+}
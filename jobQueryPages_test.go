@@ -0,0 +1,104 @@
+package go_salesforce_api_client_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	go_salesforce_api_client "github.com/MASA-JAPAN/go-salesforce-api-client"
+)
+
+// newResultPagesServer serves three result pages, listed across two resultPages responses
+func newResultPagesServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	pages := map[string]string{
+		"p1": "Id,Name\n001A,Acme\n",
+		"p2": "Id,Name\n001B,Globex\n",
+		"p3": "Id,Name\n001C,Initech\n",
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/services/data/v62.0/jobs/query/job_id/resultPages":
+			if r.URL.Query().Get("locator") == "" {
+				_, _ = io.WriteString(w, `{"resultPages":[
+					{"resultLink":"/services/data/v62.0/jobs/query/job_id/results?locator=p1"},
+					{"resultLink":"/services/data/v62.0/jobs/query/job_id/results?locator=p2"}
+				],"nextRecordsUrl":"/services/data/v62.0/jobs/query/job_id/resultPages?locator=more","done":false}`)
+				return
+			}
+			_, _ = io.WriteString(w, `{"resultPages":[{"resultLink":"/services/data/v62.0/jobs/query/job_id/results?locator=p3"}],"done":true}`)
+		case "/services/data/v62.0/jobs/query/job_id/results":
+			locator := r.URL.Query().Get("locator")
+			// Delay the first page so later pages finish first
+			if locator == "p1" {
+				time.Sleep(20 * time.Millisecond)
+			}
+			_, _ = io.WriteString(w, pages[locator])
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestGetJobQueryResultPages(t *testing.T) {
+	t.Parallel()
+	server := newResultPagesServer(t)
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	pages, err := client.GetJobQueryResultPages("job_id", 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(pages) != 3 {
+		t.Errorf("Expected 3 pages, got: %d", len(pages))
+	}
+}
+
+func TestDownloadJobQueryResultsParallel(t *testing.T) {
+	t.Parallel()
+	server := newResultPagesServer(t)
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	var out strings.Builder
+	err := client.DownloadJobQueryResultsParallel("job_id", &out, go_salesforce_api_client.ParallelDownloadOptions{Workers: 2})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	expected := "Id,Name\n001A,Acme\n001B,Globex\n001C,Initech\n"
+	if out.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, out.String())
+	}
+}
+
+func TestDownloadJobQueryResultPagesToDir(t *testing.T) {
+	t.Parallel()
+	server := newResultPagesServer(t)
+	defer server.Close()
+
+	dir := t.TempDir()
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	paths, err := client.DownloadJobQueryResultPagesToDir("job_id", dir, go_salesforce_api_client.ParallelDownloadOptions{Workers: 3})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(paths) != 3 || paths[2] != filepath.Join(dir, "job_id-00003.csv") {
+		t.Fatalf("Unexpected paths: %v", paths)
+	}
+	data, err := os.ReadFile(paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "Id,Name\n001A,Acme\n" {
+		t.Errorf("Unexpected first page: %q", data)
+	}
+}
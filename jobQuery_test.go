@@ -1,11 +1,16 @@
 package go_salesforce_api_client_test
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	go_salesforce_api_client "github.com/MASA-JAPAN/go-salesforce-api-client"
 )
@@ -160,3 +165,247 @@ func TestGetJobQueryResultsParsed_InvalidCSV(t *testing.T) {
 		t.Error("Expected CSV parse error, got nil")
 	}
 }
+
+func TestWaitForJobQuery(t *testing.T) {
+	t.Parallel()
+	var polls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		state := "InProgress"
+		if atomic.AddInt32(&polls, 1) >= 3 {
+			state = "JobComplete"
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{"id":"job_id","state":"`+state+`","numberRecordsProcessed":42,"retries":0,"totalProcessingTime":1234}`)
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	var progress []string
+	job, err := client.WaitForJobQuery(context.Background(), "job_id", go_salesforce_api_client.JobQueryWaitOptions{
+		WaitOptions: go_salesforce_api_client.WaitOptions{PollInterval: time.Millisecond},
+		OnProgress: func(job *go_salesforce_api_client.JobQueryResponse) {
+			progress = append(progress, job.State)
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if job.State != "JobComplete" || job.NumberRecordsProcessed != 42 || job.TotalProcessingTime != 1234 {
+		t.Errorf("Unexpected job: %+v", job)
+	}
+	if len(progress) != 3 {
+		t.Errorf("Expected 3 progress callbacks, got: %v", progress)
+	}
+}
+
+func TestWaitForJobQuery_Failed(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{"id":"job_id","state":"Failed","errorMessage":"INVALID_FIELD: No such column"}`)
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	job, err := client.WaitForJobQuery(context.Background(), "job_id", go_salesforce_api_client.JobQueryWaitOptions{})
+	if !errors.Is(err, go_salesforce_api_client.ErrJobFailed) {
+		t.Fatalf("Expected ErrJobFailed, got: %v", err)
+	}
+	var stateErr *go_salesforce_api_client.JobStateError
+	if !errors.As(err, &stateErr) || stateErr.ErrorMessage != "INVALID_FIELD: No such column" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if job == nil || job.State != "Failed" {
+		t.Errorf("Expected last job details, got: %+v", job)
+	}
+}
+
+func TestWaitForJobQuery_Timeout(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{"id":"job_id","state":"InProgress"}`)
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	_, err := client.WaitForJobQuery(context.Background(), "job_id", go_salesforce_api_client.JobQueryWaitOptions{
+		WaitOptions: go_salesforce_api_client.WaitOptions{PollInterval: 5 * time.Millisecond, Timeout: 50 * time.Millisecond},
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected deadline exceeded, got: %v", err)
+	}
+}
+
+// newPagedResultsServer serves CSV result pages linked by Sforce-Locator headers
+func newPagedResultsServer(t *testing.T, pages []string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := 0
+		switch r.URL.Query().Get("locator") {
+		case "":
+		case "page2":
+			page = 1
+		default:
+			t.Errorf("Unexpected locator %s", r.URL.Query().Get("locator"))
+		}
+		locator := "null"
+		if page == 0 {
+			locator = "page2"
+		}
+		w.Header().Set("Sforce-Locator", locator)
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, pages[page])
+	}))
+}
+
+func TestStreamJobQueryResults(t *testing.T) {
+	t.Parallel()
+	server := newPagedResultsServer(t, []string{
+		"Id,Name\n001ABC,Acme Corp\n",
+		"Id,Name\n002DEF,\"Global, Inc\"\n",
+	})
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	var out strings.Builder
+	if err := client.StreamJobQueryResults("job_id", 0, &out); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	expected := "Id,Name\n001ABC,Acme Corp\n002DEF,\"Global, Inc\"\n"
+	if out.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, out.String())
+	}
+}
+
+func TestJobQueryResultIterator(t *testing.T) {
+	t.Parallel()
+	server := newPagedResultsServer(t, []string{
+		"Id\tName\n001ABC\tAcme Corp\n",
+		"Id\tName\n002DEF\tGlobal Inc\n003GHI\tInitech\n",
+	})
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	it := client.NewJobQueryResultIterator("job_id", 1000)
+	defer it.Close()
+
+	var names []string
+	for it.Next() {
+		names = append(names, it.Row()["Name"])
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if strings.Join(names, "|") != "Acme Corp|Global Inc|Initech" {
+		t.Errorf("Unexpected rows: %v", names)
+	}
+}
+
+func TestJobQueryResultIterator_Error(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	it := client.NewJobQueryResultIterator("job_id", 0)
+	if it.Next() {
+		t.Fatal("Expected no rows")
+	}
+	if it.Err() == nil {
+		t.Error("Expected an error")
+	}
+}
+
+func TestCreateJobQueryWithOptions(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("Failed to decode request: %s", err)
+		}
+		if payload["operation"] != "queryAll" || payload["columnDelimiter"] != "SEMICOLON" || payload["lineEnding"] != "CRLF" {
+			t.Errorf("Unexpected payload: %v", payload)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{"id":"job_id","state":"UploadComplete","operation":"queryAll","columnDelimiter":"SEMICOLON","lineEnding":"CRLF"}`)
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	job, err := client.CreateJobQueryWithOptions("SELECT Id FROM Account", go_salesforce_api_client.JobQueryOptions{
+		Operation:       go_salesforce_api_client.JobQueryOperationQueryAll,
+		ColumnDelimiter: "semicolon",
+		LineEnding:      go_salesforce_api_client.LineEndingCRLF,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if job.ColumnDelimiter != "SEMICOLON" {
+		t.Errorf("Expected SEMICOLON, got: %s", job.ColumnDelimiter)
+	}
+}
+
+func TestCreateJobQueryWithOptions_Invalid(t *testing.T) {
+	t.Parallel()
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: "http://localhost"}
+	invalid := []go_salesforce_api_client.JobQueryOptions{
+		{Operation: "insert"},
+		{ColumnDelimiter: "COLON"},
+		{LineEnding: "CR"},
+	}
+	for _, options := range invalid {
+		if _, err := client.CreateJobQueryWithOptions("SELECT Id FROM Account", options); err == nil {
+			t.Errorf("Expected error for %+v", options)
+		}
+	}
+}
+
+func TestGetJobQueryResultsParsed_Delimiters(t *testing.T) {
+	t.Parallel()
+	pages := map[string]string{
+		"tab":       "Id\tName\r\n001ABC\tAcme, Corp\r\n",
+		"pipe":      "Id|Name\n001ABC|Acme, Corp\n",
+		"semicolon": "Id;Name\n001ABC;Acme, Corp\n",
+		"caret":     "Id^Name\n001ABC^Acme, Corp\n",
+		"backquote": "Id`Name\n001ABC`Acme, Corp\n",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, pages[strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/services/data/v58.0/jobs/query/"), "/results")])
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	for jobID := range pages {
+		results, _, err := client.GetJobQueryResultsParsed(jobID, "", 1000)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got: %v", jobID, err)
+		}
+		if len(results) != 1 || results[0]["Name"] != "Acme, Corp" {
+			t.Errorf("%s: unexpected results: %v", jobID, results)
+		}
+	}
+}
+
+func TestGetJobQueryResultsParsed_SingleColumn(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, "Name\nAcme, Corp\n")
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	results, _, err := client.GetJobQueryResultsParsed("job_id", "", 1000)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(results) != 1 || results[0]["Name"] != "Acme, Corp" {
+		t.Errorf("Unexpected results: %v", results)
+	}
+}
@@ -2,11 +2,13 @@ package go_salesforce_api_client
 
 import (
 	"bytes"
+	"context"
 	"encoding/xml"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 )
 
@@ -30,7 +32,8 @@ type MetadataRetrieveOptions struct {
 	PackageNames      []string
 	SinglePackage     bool
 	SpecificFiles     []string
-	UnpackageManifest string // XML manifest content
+	UnpackageManifest string           // XML manifest content, ignored when Unpackaged is set
+	Unpackaged        *PackageManifest // Components to retrieve
 }
 
 // MetadataAsyncResult represents async operation status
@@ -63,6 +66,7 @@ type MetadataDeployResult struct {
 	RollbackOnError          bool
 	RunTestsEnabled          bool
 	StartDate                string
+	StateDetail              string // Current deploy step, e.g. the test class being run
 	Status                   string
 	Success                  bool
 }
@@ -234,9 +238,12 @@ var (
 	ErrInvalidManifest = errors.New("invalid package manifest")
 )
 
+// defaultMetadataAPIVersion is the Metadata API version used by the client
+const defaultMetadataAPIVersion = "58.0"
+
 // getMetadataAPIVersion returns the Metadata API version
 func (c *Client) getMetadataAPIVersion() string {
-	return "58.0"
+	return defaultMetadataAPIVersion
 }
 
 // buildSOAPEnvelope constructs a SOAP envelope for Metadata API requests
@@ -336,7 +343,7 @@ func (c *Client) DeployMetadata(zipFileBase64 string, options MetadataDeployOpti
         <met:ignoreWarnings>%t</met:ignoreWarnings>
         <met:performRetrieve>%t</met:performRetrieve>
         <met:purgeOnDelete>%t</met:purgeOnDelete>
-        <met:rollbackOnError>%t</met:rollbackOnError>
+        <met:rollbackOnError>%t</met:rollbackOnError>%s
         <met:singlePackage>%t</met:singlePackage>
         <met:testLevel>%s</met:testLevel>
       </met:DeployOptions>
@@ -349,6 +356,7 @@ func (c *Client) DeployMetadata(zipFileBase64 string, options MetadataDeployOpti
 		options.PerformRetrieve,
 		options.PurgeOnDelete,
 		options.RollbackOnError,
+		runTestsXML(options.RunTests),
 		options.SinglePackage,
 		options.TestLevel,
 	)
@@ -378,6 +386,59 @@ func (c *Client) DeployMetadata(zipFileBase64 string, options MetadataDeployOpti
 	}, nil
 }
 
+// runTestsXML builds the runTests elements of the deploy options. Salesforce
+// only runs them with TestLevel RunSpecifiedTests.
+func runTestsXML(tests []string) string {
+	var b strings.Builder
+	for _, test := range tests {
+		b.WriteString("\n        <met:runTests>")
+		_ = xml.EscapeText(&b, []byte(test))
+		b.WriteString("</met:runTests>")
+	}
+	return b.String()
+}
+
+// DeployRecentValidation quick deploys a validation that succeeded within the
+// last 10 days, without running the tests again. validationID is the ID of a
+// CheckOnly deployment that ran tests.
+func (c *Client) DeployRecentValidation(validationID string) (*MetadataAsyncResult, error) {
+	if c.AccessToken == "" || c.InstanceURL == "" {
+		return nil, errors.New("missing authentication details")
+	}
+
+	apiVersion := c.getMetadataAPIVersion()
+	endpoint := fmt.Sprintf("%s/services/Soap/m/%s", c.InstanceURL, apiVersion)
+
+	var escapedID strings.Builder
+	_ = xml.EscapeText(&escapedID, []byte(validationID))
+
+	bodyContent := fmt.Sprintf(`<met:deployRecentValidation>
+      <met:validationId>%s</met:validationId>
+    </met:deployRecentValidation>`, escapedID.String())
+
+	envelope := c.buildSOAPEnvelope(bodyContent)
+
+	responseBody, err := c.sendSOAPRequest(endpoint, envelope)
+	if err != nil {
+		return nil, err
+	}
+
+	// The result is the ID of the new deployment
+	var result struct {
+		ID string `xml:"Body>deployRecentValidationResponse>result"`
+	}
+
+	if err := xml.Unmarshal(responseBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse deploy recent validation response: %w", err)
+	}
+
+	return &MetadataAsyncResult{
+		ID:    result.ID,
+		Done:  false,
+		State: "Queued",
+	}, nil
+}
+
 // Helper types for CheckDeployStatus XML parsing
 type deployStatusResponse struct {
 	Result struct {
@@ -397,6 +458,7 @@ type deployStatusResponse struct {
 		RollbackOnError          bool             `xml:"rollbackOnError"`
 		RunTestsEnabled          bool             `xml:"runTestsEnabled"`
 		StartDate                string           `xml:"startDate"`
+		StateDetail              string           `xml:"stateDetail"`
 		Status                   string           `xml:"status"`
 		Success                  bool             `xml:"success"`
 		Details                  deployDetailsXML `xml:"details"`
@@ -584,6 +646,13 @@ func convertTestResult(xmlTest runTestResultXML) *RunTestResult {
 
 // CheckDeployStatus checks the status of an asynchronous deployment
 func (c *Client) CheckDeployStatus(asyncProcessID string) (*MetadataDeployResult, error) {
+	return c.CheckDeployStatusWithDetails(asyncProcessID, true)
+}
+
+// CheckDeployStatusWithDetails checks the status of an asynchronous
+// deployment. Component and test results are only included when
+// includeDetails is set, which keeps responses of in-progress polls small.
+func (c *Client) CheckDeployStatusWithDetails(asyncProcessID string, includeDetails bool) (*MetadataDeployResult, error) {
 	if c.AccessToken == "" || c.InstanceURL == "" {
 		return nil, errors.New("missing authentication details")
 	}
@@ -594,8 +663,8 @@ func (c *Client) CheckDeployStatus(asyncProcessID string) (*MetadataDeployResult
 	// Build checkDeployStatus request
 	bodyContent := fmt.Sprintf(`<met:checkDeployStatus>
       <met:asyncProcessId>%s</met:asyncProcessId>
-      <met:includeDetails>true</met:includeDetails>
-    </met:checkDeployStatus>`, asyncProcessID)
+      <met:includeDetails>%t</met:includeDetails>
+    </met:checkDeployStatus>`, asyncProcessID, includeDetails)
 
 	envelope := c.buildSOAPEnvelope(bodyContent)
 
@@ -628,6 +697,7 @@ func (c *Client) CheckDeployStatus(asyncProcessID string) (*MetadataDeployResult
 		RollbackOnError:          response.Result.RollbackOnError,
 		RunTestsEnabled:          response.Result.RunTestsEnabled,
 		StartDate:                response.Result.StartDate,
+		StateDetail:              response.Result.StateDetail,
 		Status:                   response.Result.Status,
 		Success:                  response.Result.Success,
 	}
@@ -642,7 +712,9 @@ func (c *Client) CheckDeployStatus(asyncProcessID string) (*MetadataDeployResult
 	return result, nil
 }
 
-// CancelDeploy cancels an in-progress deployment
+// CancelDeploy cancels an in-progress deployment. State is Canceled when
+// Salesforce canceled it right away and Canceling while the cancel is still
+// in progress; use CancelDeployAndWait to wait for it.
 func (c *Client) CancelDeploy(asyncProcessID string) (*MetadataAsyncResult, error) {
 	if c.AccessToken == "" || c.InstanceURL == "" {
 		return nil, errors.New("missing authentication details")
@@ -673,13 +745,38 @@ func (c *Client) CancelDeploy(asyncProcessID string) (*MetadataAsyncResult, erro
 		return nil, fmt.Errorf("failed to parse cancel deploy response: %w", err)
 	}
 
+	state := "Canceled"
+	if !result.Done {
+		state = "Canceling"
+	}
+
 	return &MetadataAsyncResult{
 		ID:    result.ID,
 		Done:  result.Done,
-		State: "Canceled",
+		State: state,
 	}, nil
 }
 
+// CancelDeployAndWait cancels an in-progress deployment and waits until
+// Salesforce has stopped it. The final deploy status is returned; its Status
+// is Canceled unless the deployment finished before the cancel took effect.
+func (c *Client) CancelDeployAndWait(ctx context.Context, asyncProcessID string, options WaitOptions) (*MetadataDeployResult, error) {
+	if _, err := c.CancelDeploy(asyncProcessID); err != nil {
+		return nil, err
+	}
+
+	var result *MetadataDeployResult
+	err := poll(ctx, options, func() (bool, error) {
+		var err error
+		result, err = c.CheckDeployStatus(asyncProcessID)
+		if err != nil {
+			return false, err
+		}
+		return result.Done, nil
+	})
+	return result, err
+}
+
 // RetrieveMetadata initiates an asynchronous metadata retrieval
 func (c *Client) RetrieveMetadata(options MetadataRetrieveOptions) (*MetadataAsyncResult, error) {
 	if c.AccessToken == "" || c.InstanceURL == "" {
@@ -693,43 +790,11 @@ func (c *Client) RetrieveMetadata(options MetadataRetrieveOptions) (*MetadataAsy
 
 	endpoint := fmt.Sprintf("%s/services/Soap/m/%s", c.InstanceURL, apiVersion)
 
-	// Strip XML declaration from manifest if present
-	manifest := options.UnpackageManifest
-	manifest = strings.TrimSpace(manifest)
-	if strings.HasPrefix(manifest, "<?xml") {
-		// Find the end of the XML declaration and remove it
-		if endIdx := strings.Index(manifest, "?>"); endIdx != -1 {
-			manifest = strings.TrimSpace(manifest[endIdx+2:])
-		}
-	}
-
-	// Extract content from Package element (remove <Package> wrapper)
-	// The API expects the inner content, not the Package element itself
-	packageContent := manifest
-	if strings.Contains(manifest, "<Package") {
-		// Find the start of Package content
-		if startIdx := strings.Index(manifest, ">"); startIdx != -1 {
-			content := manifest[startIdx+1:]
-			// Find the end tag and remove it
-			if endIdx := strings.LastIndex(content, "</Package>"); endIdx != -1 {
-				packageContent = strings.TrimSpace(content[:endIdx])
-			}
-		}
+	bodyContent, err := retrieveRequestXML(apiVersion, options)
+	if err != nil {
+		return nil, err
 	}
 
-	// Build retrieve request
-	bodyContent := fmt.Sprintf(`<met:retrieve>
-      <met:retrieveRequest>
-        <met:apiVersion>%s</met:apiVersion>
-        <met:singlePackage>%t</met:singlePackage>
-        <met:unpackaged>%s</met:unpackaged>
-      </met:retrieveRequest>
-    </met:retrieve>`,
-		apiVersion,
-		options.SinglePackage,
-		packageContent,
-	)
-
 	envelope := c.buildSOAPEnvelope(bodyContent)
 
 	responseBody, err := c.sendSOAPRequest(endpoint, envelope)
@@ -752,8 +817,89 @@ func (c *Client) RetrieveMetadata(options MetadataRetrieveOptions) (*MetadataAsy
 	}, nil
 }
 
+// retrieveRequestXML builds the retrieve request body, encoding the manifest
+// elements in the Metadata API namespace
+func retrieveRequestXML(apiVersion string, options MetadataRetrieveOptions) (string, error) {
+	manifest := options.Unpackaged
+	if manifest == nil && strings.TrimSpace(options.UnpackageManifest) != "" {
+		parsed, err := ParsePackageManifest([]byte(options.UnpackageManifest))
+		if err != nil {
+			return "", err
+		}
+		manifest = parsed
+	}
+
+	var b strings.Builder
+	b.WriteString("<met:retrieve>\n      <met:retrieveRequest>\n")
+	writeElement := func(name, value string) {
+		b.WriteString("        <met:" + name + ">")
+		_ = xml.EscapeText(&b, []byte(value))
+		b.WriteString("</met:" + name + ">\n")
+	}
+
+	writeElement("apiVersion", apiVersion)
+	for _, name := range options.PackageNames {
+		writeElement("packageNames", name)
+	}
+	writeElement("singlePackage", strconv.FormatBool(options.SinglePackage))
+	for _, file := range options.SpecificFiles {
+		writeElement("specificFiles", file)
+	}
+	if manifest != nil {
+		unpackaged, err := manifest.unpackagedXML()
+		if err != nil {
+			return "", err
+		}
+		b.WriteString("        " + unpackaged + "\n")
+	}
+
+	b.WriteString("      </met:retrieveRequest>\n    </met:retrieve>")
+	return b.String(), nil
+}
+
+// filePropertyXML is the SOAP representation of a FileProperty
+type filePropertyXML struct {
+	CreatedByID        string `xml:"createdById"`
+	CreatedByName      string `xml:"createdByName"`
+	CreatedDate        string `xml:"createdDate"`
+	FileName           string `xml:"fileName"`
+	FullName           string `xml:"fullName"`
+	ID                 string `xml:"id"`
+	LastModifiedByID   string `xml:"lastModifiedById"`
+	LastModifiedByName string `xml:"lastModifiedByName"`
+	LastModifiedDate   string `xml:"lastModifiedDate"`
+	ManageableState    string `xml:"manageableState"`
+	NamespacePrefix    string `xml:"namespacePrefix"`
+	Type               string `xml:"type"`
+}
+
+// toFileProperty converts the SOAP representation into a FileProperty
+func (fp filePropertyXML) toFileProperty() FileProperty {
+	return FileProperty{
+		CreatedByID:        fp.CreatedByID,
+		CreatedByName:      fp.CreatedByName,
+		CreatedDate:        fp.CreatedDate,
+		FileName:           fp.FileName,
+		FullName:           fp.FullName,
+		ID:                 fp.ID,
+		LastModifiedByID:   fp.LastModifiedByID,
+		LastModifiedByName: fp.LastModifiedByName,
+		LastModifiedDate:   fp.LastModifiedDate,
+		ManageableState:    fp.ManageableState,
+		NamespacePrefix:    fp.NamespacePrefix,
+		Type:               fp.Type,
+	}
+}
+
 // CheckRetrieveStatus checks the status of an asynchronous retrieval
 func (c *Client) CheckRetrieveStatus(asyncProcessID string) (*MetadataRetrieveResult, error) {
+	return c.CheckRetrieveStatusWithZip(asyncProcessID, true)
+}
+
+// CheckRetrieveStatusWithZip checks the status of an asynchronous retrieval.
+// The zip file is only included when includeZip is set; Salesforce deletes
+// the retrieve result once a completed zip has been returned.
+func (c *Client) CheckRetrieveStatusWithZip(asyncProcessID string, includeZip bool) (*MetadataRetrieveResult, error) {
 	if c.AccessToken == "" || c.InstanceURL == "" {
 		return nil, errors.New("missing authentication details")
 	}
@@ -763,8 +909,8 @@ func (c *Client) CheckRetrieveStatus(asyncProcessID string) (*MetadataRetrieveRe
 
 	bodyContent := fmt.Sprintf(`<met:checkRetrieveStatus>
       <met:asyncProcessId>%s</met:asyncProcessId>
-      <met:includeZip>true</met:includeZip>
-    </met:checkRetrieveStatus>`, asyncProcessID)
+      <met:includeZip>%t</met:includeZip>
+    </met:checkRetrieveStatus>`, asyncProcessID, includeZip)
 
 	envelope := c.buildSOAPEnvelope(bodyContent)
 
@@ -776,29 +922,16 @@ func (c *Client) CheckRetrieveStatus(asyncProcessID string) (*MetadataRetrieveRe
 	// Parse retrieve result
 	var response struct {
 		Result struct {
-			Done            bool   `xml:"done"`
-			ErrorMessage    string `xml:"errorMessage"`
-			ErrorStatusCode string `xml:"errorStatusCode"`
-			ID              string `xml:"id"`
-			State           string `xml:"state"`
-			Status          string `xml:"status"`
-			Success         bool   `xml:"success"`
-			ZipFile         string `xml:"zipFile"`
-			FileProperties  []struct {
-				CreatedByID        string `xml:"createdById"`
-				CreatedByName      string `xml:"createdByName"`
-				CreatedDate        string `xml:"createdDate"`
-				FileName           string `xml:"fileName"`
-				FullName           string `xml:"fullName"`
-				ID                 string `xml:"id"`
-				LastModifiedByID   string `xml:"lastModifiedById"`
-				LastModifiedByName string `xml:"lastModifiedByName"`
-				LastModifiedDate   string `xml:"lastModifiedDate"`
-				ManageableState    string `xml:"manageableState"`
-				NamespacePrefix    string `xml:"namespacePrefix"`
-				Type               string `xml:"type"`
-			} `xml:"fileProperties"`
-			Messages []struct {
+			Done            bool              `xml:"done"`
+			ErrorMessage    string            `xml:"errorMessage"`
+			ErrorStatusCode string            `xml:"errorStatusCode"`
+			ID              string            `xml:"id"`
+			State           string            `xml:"state"`
+			Status          string            `xml:"status"`
+			Success         bool              `xml:"success"`
+			ZipFile         string            `xml:"zipFile"`
+			FileProperties  []filePropertyXML `xml:"fileProperties"`
+			Messages        []struct {
 				FileName string `xml:"fileName"`
 				Problem  string `xml:"problem"`
 			} `xml:"messages"`
@@ -823,20 +956,7 @@ func (c *Client) CheckRetrieveStatus(asyncProcessID string) (*MetadataRetrieveRe
 
 	// Convert file properties
 	for _, fp := range response.Result.FileProperties {
-		result.FileProperties = append(result.FileProperties, FileProperty{
-			CreatedByID:        fp.CreatedByID,
-			CreatedByName:      fp.CreatedByName,
-			CreatedDate:        fp.CreatedDate,
-			FileName:           fp.FileName,
-			FullName:           fp.FullName,
-			ID:                 fp.ID,
-			LastModifiedByID:   fp.LastModifiedByID,
-			LastModifiedByName: fp.LastModifiedByName,
-			LastModifiedDate:   fp.LastModifiedDate,
-			ManageableState:    fp.ManageableState,
-			NamespacePrefix:    fp.NamespacePrefix,
-			Type:               fp.Type,
-		})
+		result.FileProperties = append(result.FileProperties, fp.toFileProperty())
 	}
 
 	// Convert messages
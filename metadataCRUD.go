@@ -0,0 +1,477 @@
+package go_salesforce_api_client
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// maxMetadataCRUDComponents is the number of components the CRUD calls accept per call
+const maxMetadataCRUDComponents = 10
+
+// xmlSchemaInstanceNamespace declares the xsi:type attribute of CRUD payloads
+const xmlSchemaInstanceNamespace = "http://www.w3.org/2001/XMLSchema-instance"
+
+// ErrMetadataSaveFailed is returned when a CRUD call fails for any of its components
+var ErrMetadataSaveFailed = errors.New("metadata save failed")
+
+// MetadataComponent is a component that can be sent to the Metadata API CRUD
+// calls. MetadataXML holds any metadata type; typed structs such as
+// MetadataCustomObject cover the common ones.
+type MetadataComponent interface {
+	MetadataType() string
+	MetadataFullName() string
+}
+
+// MetadataXML is a component of any metadata type. Content holds the XML
+// elements of the component other than fullName, as in its -meta.xml file.
+type MetadataXML struct {
+	Type     string
+	FullName string
+	Content  string
+}
+
+// MetadataType returns the metadata type of the component
+func (m MetadataXML) MetadataType() string { return m.Type }
+
+// MetadataFullName returns the full name of the component
+func (m MetadataXML) MetadataFullName() string { return m.FullName }
+
+// MarshalXML encodes the component with its raw Content
+func (m MetadataXML) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(struct {
+		FullName string `xml:"fullName"`
+		Content  string `xml:",innerxml"`
+	}{m.FullName, m.Content}, start)
+}
+
+// Decode decodes the component into a typed struct such as MetadataCustomField
+func (m MetadataXML) Decode(v any) error {
+	var doc bytes.Buffer
+	doc.WriteString("<metadata><fullName>")
+	if err := xml.EscapeText(&doc, []byte(m.FullName)); err != nil {
+		return err
+	}
+	doc.WriteString("</fullName>")
+	doc.WriteString(m.Content)
+	doc.WriteString("</metadata>")
+	return xml.Unmarshal(doc.Bytes(), v)
+}
+
+// MetadataError describes why a CRUD call failed for a component
+type MetadataError struct {
+	StatusCode string
+	Message    string
+	Fields     []string
+}
+
+// SaveResult is the result of createMetadata, updateMetadata or renameMetadata for a component
+type SaveResult struct {
+	FullName string
+	Success  bool
+	Errors   []MetadataError
+}
+
+// UpsertResult is the result of upsertMetadata for a component
+type UpsertResult struct {
+	FullName string
+	Success  bool
+	Created  bool // Whether the component was created rather than updated
+	Errors   []MetadataError
+}
+
+// DeleteResult is the result of deleteMetadata for a component
+type DeleteResult struct {
+	FullName string
+	Success  bool
+	Errors   []MetadataError
+}
+
+// metadataErrorXML is the SOAP representation of a MetadataError
+type metadataErrorXML struct {
+	StatusCode string   `xml:"statusCode"`
+	Message    string   `xml:"message"`
+	Fields     []string `xml:"fields"`
+}
+
+// saveResultXML is the SOAP representation of save, upsert and delete results
+type saveResultXML struct {
+	FullName string             `xml:"fullName"`
+	Success  bool               `xml:"success"`
+	Created  bool               `xml:"created"`
+	Errors   []metadataErrorXML `xml:"errors"`
+}
+
+// convertMetadataErrors converts SOAP errors to MetadataErrors
+func convertMetadataErrors(xmlErrors []metadataErrorXML) []MetadataError {
+	var errs []MetadataError
+	for _, e := range xmlErrors {
+		errs = append(errs, MetadataError(e))
+	}
+	return errs
+}
+
+// metadataSaveError describes the first failed component of a CRUD call
+func metadataSaveError(failed int, fullName string, errs []MetadataError) error {
+	detail := "unknown error"
+	if len(errs) > 0 {
+		detail = fmt.Sprintf("%s: %s", errs[0].StatusCode, errs[0].Message)
+	}
+	return fmt.Errorf("%w: %d components failed, first: %s: %s", ErrMetadataSaveFailed, failed, fullName, detail)
+}
+
+// readMetadataRequest is the SOAP representation of a readMetadata call
+type readMetadataRequest struct {
+	XMLName   xml.Name `xml:"met:readMetadata"`
+	Type      string   `xml:"met:type"`
+	FullNames []string `xml:"met:fullNames"`
+}
+
+// deleteMetadataRequest is the SOAP representation of a deleteMetadata call
+type deleteMetadataRequest struct {
+	XMLName   xml.Name `xml:"met:deleteMetadata"`
+	Type      string   `xml:"met:type"`
+	FullNames []string `xml:"met:fullNames"`
+}
+
+// renameMetadataRequest is the SOAP representation of a renameMetadata call
+type renameMetadataRequest struct {
+	XMLName     xml.Name `xml:"met:renameMetadata"`
+	Type        string   `xml:"met:type"`
+	OldFullName string   `xml:"met:oldFullName"`
+	NewFullName string   `xml:"met:newFullName"`
+}
+
+// metadataComponentsXML encodes the components of a create, update or upsert call
+func metadataComponentsXML(operation string, components []MetadataComponent) (string, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "<met:%s>", operation)
+
+	enc := xml.NewEncoder(&buf)
+	for _, component := range components {
+		start := xml.StartElement{
+			Name: xml.Name{Space: metadataNamespace, Local: "metadata"},
+			Attr: []xml.Attr{
+				{Name: xml.Name{Local: "xmlns:xsi"}, Value: xmlSchemaInstanceNamespace},
+				{Name: xml.Name{Local: "xsi:type"}, Value: component.MetadataType()},
+			},
+		}
+		if err := enc.EncodeElement(component, start); err != nil {
+			return "", fmt.Errorf("failed to encode %s %s: %w", component.MetadataType(), component.MetadataFullName(), err)
+		}
+	}
+	if err := enc.Flush(); err != nil {
+		return "", err
+	}
+
+	fmt.Fprintf(&buf, "</met:%s>", operation)
+	return buf.String(), nil
+}
+
+// callMetadataCRUD sends a CRUD call and returns the raw response body
+func (c *Client) callMetadataCRUD(bodyContent string) ([]byte, error) {
+	if c.AccessToken == "" || c.InstanceURL == "" {
+		return nil, errors.New("missing authentication details")
+	}
+
+	endpoint := fmt.Sprintf("%s/services/Soap/m/%s", c.InstanceURL, c.getMetadataAPIVersion())
+	envelope := c.buildSOAPEnvelope(bodyContent)
+
+	return c.sendSOAPRequest(endpoint, envelope)
+}
+
+// saveMetadata sends the components in batches of ten and returns the raw results
+func (c *Client) saveMetadata(operation string, components []MetadataComponent) ([]saveResultXML, error) {
+	var results []saveResultXML
+	for start := 0; start < len(components); start += maxMetadataCRUDComponents {
+		end := start + maxMetadataCRUDComponents
+		if end > len(components) {
+			end = len(components)
+		}
+
+		bodyContent, err := metadataComponentsXML(operation, components[start:end])
+		if err != nil {
+			return nil, err
+		}
+
+		responseBody, err := c.callMetadataCRUD(bodyContent)
+		if err != nil {
+			return nil, err
+		}
+
+		batch, err := parseSaveResults(responseBody, operation)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, batch...)
+	}
+
+	return results, nil
+}
+
+// parseSaveResults parses the results of a CRUD call response
+func parseSaveResults(responseBody []byte, operation string) ([]saveResultXML, error) {
+	var response struct {
+		Body struct {
+			Response struct {
+				Results []saveResultXML `xml:"result"`
+			} `xml:",any"`
+		} `xml:"Body"`
+	}
+	if err := xml.Unmarshal(responseBody, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse %s response: %w", operation, err)
+	}
+	return response.Body.Response.Results, nil
+}
+
+// toSaveResults converts raw results and reports the first failure
+func toSaveResults(xmlResults []saveResultXML) ([]SaveResult, error) {
+	results := make([]SaveResult, 0, len(xmlResults))
+	firstFailure := -1
+	failed := 0
+	for _, r := range xmlResults {
+		results = append(results, SaveResult{FullName: r.FullName, Success: r.Success, Errors: convertMetadataErrors(r.Errors)})
+		if !r.Success {
+			if firstFailure < 0 {
+				firstFailure = len(results) - 1
+			}
+			failed++
+		}
+	}
+	if firstFailure >= 0 {
+		return results, metadataSaveError(failed, results[firstFailure].FullName, results[firstFailure].Errors)
+	}
+	return results, nil
+}
+
+// CreateMetadata creates components synchronously, ten per call. Failed
+// components are reported in the results together with ErrMetadataSaveFailed.
+func (c *Client) CreateMetadata(components ...MetadataComponent) ([]SaveResult, error) {
+	xmlResults, err := c.saveMetadata("createMetadata", components)
+	if err != nil {
+		return nil, err
+	}
+	return toSaveResults(xmlResults)
+}
+
+// UpdateMetadata replaces components synchronously, ten per call. Properties
+// missing from a component are reset, so typed structs should only be used
+// for components read with ReadMetadataAs or fully described by the struct.
+// Failed components are reported in the results together with ErrMetadataSaveFailed.
+func (c *Client) UpdateMetadata(components ...MetadataComponent) ([]SaveResult, error) {
+	xmlResults, err := c.saveMetadata("updateMetadata", components)
+	if err != nil {
+		return nil, err
+	}
+	return toSaveResults(xmlResults)
+}
+
+// UpsertMetadata creates or replaces components synchronously, ten per call.
+// Failed components are reported in the results together with ErrMetadataSaveFailed.
+func (c *Client) UpsertMetadata(components ...MetadataComponent) ([]UpsertResult, error) {
+	xmlResults, err := c.saveMetadata("upsertMetadata", components)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]UpsertResult, 0, len(xmlResults))
+	firstFailure := -1
+	failed := 0
+	for _, r := range xmlResults {
+		results = append(results, UpsertResult{
+			FullName: r.FullName,
+			Success:  r.Success,
+			Created:  r.Created,
+			Errors:   convertMetadataErrors(r.Errors),
+		})
+		if !r.Success {
+			if firstFailure < 0 {
+				firstFailure = len(results) - 1
+			}
+			failed++
+		}
+	}
+	if firstFailure >= 0 {
+		return results, metadataSaveError(failed, results[firstFailure].FullName, results[firstFailure].Errors)
+	}
+
+	return results, nil
+}
+
+// DeleteMetadata deletes components of a metadata type synchronously, ten per
+// call. Failed components are reported in the results together with ErrMetadataSaveFailed.
+func (c *Client) DeleteMetadata(metadataType string, fullNames ...string) ([]DeleteResult, error) {
+	var xmlResults []saveResultXML
+	for start := 0; start < len(fullNames); start += maxMetadataCRUDComponents {
+		end := start + maxMetadataCRUDComponents
+		if end > len(fullNames) {
+			end = len(fullNames)
+		}
+
+		bodyContent, err := xml.Marshal(deleteMetadataRequest{Type: metadataType, FullNames: fullNames[start:end]})
+		if err != nil {
+			return nil, err
+		}
+
+		responseBody, err := c.callMetadataCRUD(string(bodyContent))
+		if err != nil {
+			return nil, err
+		}
+
+		batch, err := parseSaveResults(responseBody, "deleteMetadata")
+		if err != nil {
+			return nil, err
+		}
+		xmlResults = append(xmlResults, batch...)
+	}
+
+	saveResults, err := toSaveResults(xmlResults)
+	results := make([]DeleteResult, 0, len(saveResults))
+	for _, r := range saveResults {
+		results = append(results, DeleteResult(r))
+	}
+
+	return results, err
+}
+
+// RenameMetadata renames a component. A failed rename is reported in the
+// result together with ErrMetadataSaveFailed.
+func (c *Client) RenameMetadata(metadataType, oldFullName, newFullName string) (*SaveResult, error) {
+	bodyContent, err := xml.Marshal(renameMetadataRequest{Type: metadataType, OldFullName: oldFullName, NewFullName: newFullName})
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, err := c.callMetadataCRUD(string(bodyContent))
+	if err != nil {
+		return nil, err
+	}
+
+	xmlResults, err := parseSaveResults(responseBody, "renameMetadata")
+	if err != nil {
+		return nil, err
+	}
+	if len(xmlResults) == 0 {
+		return nil, errors.New("renameMetadata response contains no result")
+	}
+
+	results, err := toSaveResults(xmlResults[:1])
+	return &results[0], err
+}
+
+// ReadMetadata reads components of a metadata type, ten per call. Names that
+// don't exist are left out of the result.
+func (c *Client) ReadMetadata(metadataType string, fullNames ...string) ([]MetadataXML, error) {
+	var components []MetadataXML
+	for start := 0; start < len(fullNames); start += maxMetadataCRUDComponents {
+		end := start + maxMetadataCRUDComponents
+		if end > len(fullNames) {
+			end = len(fullNames)
+		}
+
+		bodyContent, err := xml.Marshal(readMetadataRequest{Type: metadataType, FullNames: fullNames[start:end]})
+		if err != nil {
+			return nil, err
+		}
+
+		responseBody, err := c.callMetadataCRUD(string(bodyContent))
+		if err != nil {
+			return nil, err
+		}
+
+		var response struct {
+			Records []struct {
+				Type  string `xml:"type,attr"`
+				Inner []byte `xml:",innerxml"`
+			} `xml:"Body>readMetadataResponse>result>records"`
+		}
+		if err := xml.Unmarshal(responseBody, &response); err != nil {
+			return nil, fmt.Errorf("failed to parse read metadata response: %w", err)
+		}
+
+		for _, record := range response.Records {
+			fullName, content, err := splitMetadataFullName(record.Inner)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse read metadata response: %w", err)
+			}
+			if fullName == "" {
+				continue
+			}
+
+			recordType := metadataType
+			if record.Type != "" {
+				recordType = record.Type[strings.LastIndex(record.Type, ":")+1:]
+			}
+			components = append(components, MetadataXML{Type: recordType, FullName: fullName, Content: content})
+		}
+	}
+
+	return components, nil
+}
+
+// ReadMetadataAs reads components into a typed struct such as
+// MetadataPermissionSet. T may also be a pointer to a struct.
+func ReadMetadataAs[T MetadataComponent](c *Client, fullNames ...string) ([]T, error) {
+	components, err := c.ReadMetadata(metadataTypeOf[T](), fullNames...)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]T, 0, len(components))
+	for _, component := range components {
+		var v T
+		if err := component.Decode(&v); err != nil {
+			return nil, fmt.Errorf("failed to decode %s %s: %w", component.Type, component.FullName, err)
+		}
+		results = append(results, v)
+	}
+
+	return results, nil
+}
+
+// metadataTypeOf returns the metadata type of T without calling its methods
+// on a nil pointer
+func metadataTypeOf[T MetadataComponent]() string {
+	t := reflect.TypeFor[T]()
+	if t.Kind() == reflect.Pointer {
+		return reflect.New(t.Elem()).Interface().(MetadataComponent).MetadataType()
+	}
+	var zero T
+	return zero.MetadataType()
+}
+
+// splitMetadataFullName separates the top-level fullName element from the
+// other elements of a component
+func splitMetadataFullName(inner []byte) (string, string, error) {
+	d := xml.NewDecoder(bytes.NewReader(inner))
+	depth := 0
+	for {
+		offset := d.InputOffset()
+		tok, err := d.Token()
+		if err == io.EOF {
+			return "", strings.TrimSpace(string(inner)), nil
+		}
+		if err != nil {
+			return "", "", err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if depth == 0 && t.Name.Local == "fullName" {
+				var fullName string
+				if err := d.DecodeElement(&fullName, &t); err != nil {
+					return "", "", err
+				}
+				content := string(inner[:offset]) + string(inner[d.InputOffset():])
+				return strings.TrimSpace(fullName), strings.TrimSpace(content), nil
+			}
+			depth++
+		case xml.EndElement:
+			depth--
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package go_salesforce_api_client_test
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	go_salesforce_api_client "github.com/MASA-JAPAN/go-salesforce-api-client"
+)
+
+func TestCreateMetadata(t *testing.T) {
+	t.Parallel()
+
+	var requestBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requestBody = string(body)
+		writeSOAPResponse(w, `<createMetadataResponse xmlns="http://soap.sforce.com/2006/04/metadata">
+			<result><fullName>Account.Region__c</fullName><success>true</success></result>
+			<result><errors><fields>url</fields><message>Invalid URL</message><statusCode>FIELD_INTEGRITY_EXCEPTION</statusCode></errors><fullName>Example</fullName><success>false</success></result>
+		</createMetadataResponse>`)
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	results, err := client.CreateMetadata(
+		go_salesforce_api_client.MetadataCustomField{
+			FullName: "Account.Region__c",
+			Label:    "Region",
+			Type:     "Picklist",
+			ValueSet: go_salesforce_api_client.NewPicklistValueSet("East", "West"),
+		},
+		go_salesforce_api_client.MetadataXML{
+			Type:     "RemoteSiteSetting",
+			FullName: "Example",
+			Content:  "<isActive>true</isActive><url>example</url>",
+		},
+	)
+	if !errors.Is(err, go_salesforce_api_client.ErrMetadataSaveFailed) {
+		t.Fatalf("Expected ErrMetadataSaveFailed, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "Example: FIELD_INTEGRITY_EXCEPTION: Invalid URL") {
+		t.Errorf("Expected first failure in error, got: %v", err)
+	}
+	if len(results) != 2 || !results[0].Success || results[1].Success {
+		t.Fatalf("Unexpected results: %+v", results)
+	}
+	if len(results[1].Errors) != 1 || results[1].Errors[0].Fields[0] != "url" {
+		t.Errorf("Unexpected errors: %+v", results[1].Errors)
+	}
+
+	for _, expected := range []string{
+		`<met:createMetadata><metadata xmlns="http://soap.sforce.com/2006/04/metadata" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="CustomField">`,
+		`<fullName>Account.Region__c</fullName><label>Region</label><type>Picklist</type>`,
+		`<value><fullName>East</fullName><default>false</default><label>East</label></value>`,
+		`xsi:type="RemoteSiteSetting"><fullName>Example</fullName><isActive>true</isActive><url>example</url></metadata>`,
+	} {
+		if !strings.Contains(requestBody, expected) {
+			t.Errorf("Expected request to contain %s, got: %s", expected, requestBody)
+		}
+	}
+
+	var envelope struct {
+		Components []struct {
+			Type     string `xml:"type,attr"`
+			FullName string `xml:"fullName"`
+		} `xml:"Body>createMetadata>metadata"`
+	}
+	if err := xml.Unmarshal([]byte(requestBody), &envelope); err != nil {
+		t.Fatalf("Expected a well-formed request, got: %v", err)
+	}
+	if len(envelope.Components) != 2 || envelope.Components[1].Type != "RemoteSiteSetting" {
+		t.Errorf("Unexpected components: %+v", envelope.Components)
+	}
+}
+
+func TestReadMetadataAs(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(body), "<met:readMetadata><met:type>PermissionSet</met:type><met:fullNames>Sales</met:fullNames>") {
+			t.Errorf("Unexpected request: %s", body)
+		}
+		writeSOAPResponse(w, `<readMetadataResponse xmlns="http://soap.sforce.com/2006/04/metadata"><result>
+			<records xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="PermissionSet">
+				<fullName>Sales</fullName>
+				<fieldPermissions><editable>true</editable><field>Account.Region__c</field><readable>true</readable></fieldPermissions>
+				<hasActivationRequired>false</hasActivationRequired>
+				<label>Sales</label>
+				<userPermissions><enabled>true</enabled><name>ApiEnabled</name></userPermissions>
+			</records>
+			<records xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="PermissionSet"/>
+		</result></readMetadataResponse>`)
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	permissionSets, err := go_salesforce_api_client.ReadMetadataAs[go_salesforce_api_client.MetadataPermissionSet](client, "Sales", "Missing")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(permissionSets) != 1 {
+		t.Fatalf("Expected 1 permission set, got: %d", len(permissionSets))
+	}
+	ps := permissionSets[0]
+	if ps.FullName != "Sales" || ps.Label != "Sales" {
+		t.Errorf("Unexpected permission set: %+v", ps)
+	}
+	if len(ps.FieldPermissions) != 1 || !ps.FieldPermissions[0].Editable || ps.FieldPermissions[0].Field != "Account.Region__c" {
+		t.Errorf("Unexpected field permissions: %+v", ps.FieldPermissions)
+	}
+	if len(ps.UserPermissions) != 1 || ps.UserPermissions[0].Name != "ApiEnabled" {
+		t.Errorf("Unexpected user permissions: %+v", ps.UserPermissions)
+	}
+
+	components, err := client.ReadMetadata("PermissionSet", "Sales")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if components[0].Type != "PermissionSet" || strings.Contains(components[0].Content, "fullName") ||
+		!strings.HasPrefix(components[0].Content, "<fieldPermissions>") {
+		t.Errorf("Unexpected component: %+v", components[0])
+	}
+}
+
+func TestUpsertMetadata(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeSOAPResponse(w, `<upsertMetadataResponse xmlns="http://soap.sforce.com/2006/04/metadata">
+			<result><created>true</created><fullName>Invoice__c</fullName><success>true</success></result>
+		</upsertMetadataResponse>`)
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	results, err := client.UpsertMetadata(go_salesforce_api_client.MetadataCustomObject{
+		FullName:         "Invoice__c",
+		DeploymentStatus: "Deployed",
+		Label:            "Invoice",
+		NameField:        &go_salesforce_api_client.MetadataCustomField{Label: "Invoice Number", Type: "AutoNumber", DisplayFormat: "INV-{0000}"},
+		PluralLabel:      "Invoices",
+		SharingModel:     "ReadWrite",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(results) != 1 || !results[0].Created || results[0].FullName != "Invoice__c" {
+		t.Errorf("Unexpected results: %+v", results)
+	}
+}
+
+func TestDeleteMetadata_Batches(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var calls []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		body, _ := io.ReadAll(r.Body)
+		names := strings.Count(string(body), "<met:fullNames>")
+		calls = append(calls, names)
+		var results strings.Builder
+		for i := 0; i < names; i++ {
+			results.WriteString(`<result><fullName>Layout</fullName><success>true</success></result>`)
+		}
+		writeSOAPResponse(w, `<deleteMetadataResponse xmlns="http://soap.sforce.com/2006/04/metadata">`+results.String()+`</deleteMetadataResponse>`)
+	}))
+	defer server.Close()
+
+	names := make([]string, 12)
+	for i := range names {
+		names[i] = "Account-Layout"
+	}
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	results, err := client.DeleteMetadata("Layout", names...)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(calls) != 2 || calls[0] != 10 || calls[1] != 2 {
+		t.Errorf("Expected batches of 10 and 2 names, got: %v", calls)
+	}
+	if len(results) != 12 {
+		t.Errorf("Expected 12 results, got: %d", len(results))
+	}
+}
+
+func TestRenameMetadata(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(body), "<met:type>Layout</met:type><met:oldFullName>Account-Old</met:oldFullName><met:newFullName>Account-New</met:newFullName>") {
+			t.Errorf("Unexpected request: %s", body)
+		}
+		writeSOAPResponse(w, `<renameMetadataResponse xmlns="http://soap.sforce.com/2006/04/metadata">
+			<result><fullName>Account-New</fullName><success>true</success></result>
+		</renameMetadataResponse>`)
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	result, err := client.RenameMetadata("Layout", "Account-Old", "Account-New")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !result.Success || result.FullName != "Account-New" {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+}
+
+// remoteSiteSetting implements MetadataComponent with pointer receivers
+type remoteSiteSetting struct {
+	FullName string `xml:"fullName"`
+	IsActive bool   `xml:"isActive"`
+	URL      string `xml:"url"`
+}
+
+func (*remoteSiteSetting) MetadataType() string { return "RemoteSiteSetting" }
+
+func (r *remoteSiteSetting) MetadataFullName() string { return r.FullName }
+
+func TestReadMetadataAs_PointerType(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(body), "<met:type>RemoteSiteSetting</met:type>") {
+			t.Errorf("Unexpected request: %s", body)
+		}
+		writeSOAPResponse(w, `<readMetadataResponse xmlns="http://soap.sforce.com/2006/04/metadata"><result>
+			<records xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="RemoteSiteSetting">
+				<fullName>Example</fullName><isActive>true</isActive><url>https://example.com</url>
+			</records>
+		</result></readMetadataResponse>`)
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	settings, err := go_salesforce_api_client.ReadMetadataAs[*remoteSiteSetting](client, "Example")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(settings) != 1 || settings[0] == nil || !settings[0].IsActive || settings[0].URL != "https://example.com" {
+		t.Errorf("Unexpected settings: %+v", settings)
+	}
+}
@@ -0,0 +1,131 @@
+package go_salesforce_api_client
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ExtractedFile maps a retrieved component to its extracted files
+type ExtractedFile struct {
+	FileProperty FileProperty
+	Path         string // Extracted file, or directory for bundles
+	MetaPath     string // Extracted -meta.xml companion, empty when there is none
+}
+
+// ZipReader decodes the retrieved zip file
+func (r *MetadataRetrieveResult) ZipReader() (*zip.Reader, error) {
+	if r.ZipFileBase64 == "" {
+		return nil, fmt.Errorf("%w: retrieve result contains no zip file", ErrInvalidZip)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(r.ZipFileBase64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidZip, err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidZip, err)
+	}
+
+	return zr, nil
+}
+
+// FS exposes the retrieved zip file as a read-only file system
+func (r *MetadataRetrieveResult) FS() (fs.FS, error) {
+	return r.ZipReader()
+}
+
+// ExtractTo extracts the retrieved zip file into dir and returns where each
+// of the FileProperties was written. Entries that would be written outside
+// of dir are rejected with ErrInvalidZip before anything is extracted, and
+// symlinks already in dir are not followed outside of it.
+func (r *MetadataRetrieveResult) ExtractTo(dir string) ([]ExtractedFile, error) {
+	zr, err := r.ZipReader()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range zr.File {
+		if !filepath.IsLocal(filepath.FromSlash(f.Name)) || strings.Contains(f.Name, `\`) {
+			return nil, fmt.Errorf("%w: entry %q escapes the target directory", ErrInvalidZip, f.Name)
+		}
+		if !f.Mode().IsRegular() && !f.Mode().IsDir() {
+			return nil, fmt.Errorf("%w: entry %q is not a regular file", ErrInvalidZip, f.Name)
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	// Writing through a root keeps existing symlinks in dir from redirecting
+	// files outside of it
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer root.Close()
+
+	for _, f := range zr.File {
+		name := filepath.FromSlash(f.Name)
+		if f.Mode().IsDir() {
+			if err := root.MkdirAll(name, 0o755); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err := extractZipFile(root, f, name); err != nil {
+			return nil, err
+		}
+	}
+
+	extracted := make([]ExtractedFile, 0, len(r.FileProperties))
+	for _, property := range r.FileProperties {
+		file := ExtractedFile{FileProperty: property}
+		if property.FileName != "" && filepath.IsLocal(filepath.FromSlash(property.FileName)) {
+			file.Path = filepath.Join(dir, filepath.FromSlash(property.FileName))
+			if _, err := os.Stat(file.Path + metadataFileSuffix); err == nil {
+				file.MetaPath = file.Path + metadataFileSuffix
+			}
+		}
+		extracted = append(extracted, file)
+	}
+
+	return extracted, nil
+}
+
+// extractZipFile writes a single zip entry to name within root
+func extractZipFile(root *os.Root, f *zip.File, name string) error {
+	if err := root.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidZip, err)
+	}
+	defer rc.Close()
+
+	out, err := root.OpenFile(name, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(out, rc)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if errors.Is(err, zip.ErrChecksum) {
+		return fmt.Errorf("%w: %w", ErrInvalidZip, err)
+	}
+	return err
+}
@@ -0,0 +1,126 @@
+package go_salesforce_api_client_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	go_salesforce_api_client "github.com/MASA-JAPAN/go-salesforce-api-client"
+)
+
+func newRetrieveResult(t *testing.T, files map[string]string, properties ...go_salesforce_api_client.FileProperty) *go_salesforce_api_client.MetadataRetrieveResult {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("Failed to create zip entry: %v", err)
+		}
+		_, _ = w.Write([]byte(content))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Failed to close zip: %v", err)
+	}
+	return &go_salesforce_api_client.MetadataRetrieveResult{
+		Done:           true,
+		Success:        true,
+		ZipFileBase64:  base64.StdEncoding.EncodeToString(buf.Bytes()),
+		FileProperties: properties,
+	}
+}
+
+func TestMetadataRetrieveResult_ExtractTo(t *testing.T) {
+	t.Parallel()
+
+	result := newRetrieveResult(t, map[string]string{
+		"unpackaged/package.xml":                         "<Package/>",
+		"unpackaged/classes/AccountService.cls":          "public class AccountService {}",
+		"unpackaged/classes/AccountService.cls-meta.xml": "<ApexClass/>",
+		"unpackaged/objects/Account.object":              "<CustomObject/>",
+	},
+		go_salesforce_api_client.FileProperty{FileName: "unpackaged/classes/AccountService.cls", FullName: "AccountService", Type: "ApexClass"},
+		go_salesforce_api_client.FileProperty{FileName: "unpackaged/objects/Account.object", FullName: "Account", Type: "CustomObject"},
+	)
+
+	dir := t.TempDir()
+	extracted, err := result.ExtractTo(dir)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(extracted) != 2 {
+		t.Fatalf("Expected 2 extracted files, got %d", len(extracted))
+	}
+
+	class := extracted[0]
+	if class.Path != filepath.Join(dir, "unpackaged", "classes", "AccountService.cls") ||
+		class.MetaPath != class.Path+"-meta.xml" {
+		t.Errorf("Unexpected class paths: %+v", class)
+	}
+	content, err := os.ReadFile(class.Path)
+	if err != nil || string(content) != "public class AccountService {}" {
+		t.Errorf("Unexpected class content %q, %v", content, err)
+	}
+	if extracted[1].FileProperty.FullName != "Account" || extracted[1].MetaPath != "" {
+		t.Errorf("Unexpected object mapping: %+v", extracted[1])
+	}
+}
+
+func TestMetadataRetrieveResult_ExtractToRejectsTraversal(t *testing.T) {
+	t.Parallel()
+
+	result := newRetrieveResult(t, map[string]string{
+		"unpackaged/classes/A.cls": "ok",
+		"../../evil.sh":            "boom",
+	})
+
+	dir := t.TempDir()
+	if _, err := result.ExtractTo(dir); !errors.Is(err, go_salesforce_api_client.ErrInvalidZip) {
+		t.Fatalf("Expected ErrInvalidZip, got: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "unpackaged")); !os.IsNotExist(err) {
+		t.Error("Expected nothing to be extracted")
+	}
+}
+
+func TestMetadataRetrieveResult_ExtractToRejectsSymlinkEscape(t *testing.T) {
+	t.Parallel()
+
+	result := newRetrieveResult(t, map[string]string{"classes/A.cls": "class A"})
+
+	dir := t.TempDir()
+	outside := t.TempDir()
+	if err := os.Symlink(outside, filepath.Join(dir, "classes")); err != nil {
+		t.Skipf("Symlinks not supported: %v", err)
+	}
+	if _, err := result.ExtractTo(dir); err == nil {
+		t.Fatal("Expected an error for a symlink leaving the directory")
+	}
+	if _, err := os.Stat(filepath.Join(outside, "A.cls")); !os.IsNotExist(err) {
+		t.Error("Expected nothing to be written outside the directory")
+	}
+}
+
+func TestMetadataRetrieveResult_FS(t *testing.T) {
+	t.Parallel()
+
+	result := newRetrieveResult(t, map[string]string{"classes/A.cls": "class A"})
+	fsys, err := result.FS()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	content, err := fs.ReadFile(fsys, "classes/A.cls")
+	if err != nil || string(content) != "class A" {
+		t.Errorf("Unexpected content %q, %v", content, err)
+	}
+
+	empty := &go_salesforce_api_client.MetadataRetrieveResult{}
+	if _, err := empty.FS(); !errors.Is(err, go_salesforce_api_client.ErrInvalidZip) {
+		t.Errorf("Expected ErrInvalidZip for missing zip, got: %v", err)
+	}
+}
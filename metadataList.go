@@ -0,0 +1,201 @@
+package go_salesforce_api_client
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// maxListMetadataQueries is the number of queries listMetadata accepts per call
+const maxListMetadataQueries = 3
+
+// metadataFolderTypes maps folder-based metadata types to their folder type
+var metadataFolderTypes = map[string]string{
+	"Dashboard":     "DashboardFolder",
+	"Document":      "DocumentFolder",
+	"EmailTemplate": "EmailFolder",
+	"Report":        "ReportFolder",
+}
+
+// ListMetadataQuery selects the components of a metadata type to list.
+// Folder is required for folder-based types such as Report or Document.
+type ListMetadataQuery struct {
+	Type   string
+	Folder string
+}
+
+// DescribeMetadataResult describes the metadata types available in an org
+type DescribeMetadataResult struct {
+	MetadataObjects       []DescribeMetadataObject
+	OrganizationNamespace string
+	PartialSaveAllowed    bool
+	TestRequired          bool
+}
+
+// DescribeMetadataObject describes a metadata type and how it is stored in a package
+type DescribeMetadataObject struct {
+	TypeName      string   // Name used in package.xml, e.g. ApexClass
+	ChildXMLNames []string // Child types such as CustomField for CustomObject
+	DirectoryName string
+	InFolder      bool
+	MetaFile      bool
+	Suffix        string
+}
+
+// listMetadataRequest is the SOAP representation of a listMetadata call
+type listMetadataRequest struct {
+	XMLName     xml.Name               `xml:"met:listMetadata"`
+	Queries     []listMetadataQueryXML `xml:"met:queries"`
+	AsOfVersion string                 `xml:"met:asOfVersion"`
+}
+
+// listMetadataQueryXML is the SOAP representation of a ListMetadataQuery
+type listMetadataQueryXML struct {
+	Folder string `xml:"met:folder,omitempty"`
+	Type   string `xml:"met:type"`
+}
+
+// ListMetadata lists the components matching the queries. Queries are sent
+// in batches of three, the maximum accepted per call.
+func (c *Client) ListMetadata(queries ...ListMetadataQuery) ([]FileProperty, error) {
+	if c.AccessToken == "" || c.InstanceURL == "" {
+		return nil, errors.New("missing authentication details")
+	}
+
+	apiVersion := c.getMetadataAPIVersion()
+	endpoint := fmt.Sprintf("%s/services/Soap/m/%s", c.InstanceURL, apiVersion)
+
+	var properties []FileProperty
+	for start := 0; start < len(queries); start += maxListMetadataQueries {
+		end := start + maxListMetadataQueries
+		if end > len(queries) {
+			end = len(queries)
+		}
+
+		request := listMetadataRequest{AsOfVersion: apiVersion}
+		for _, query := range queries[start:end] {
+			request.Queries = append(request.Queries, listMetadataQueryXML{Folder: query.Folder, Type: query.Type})
+		}
+
+		bodyContent, err := xml.Marshal(request)
+		if err != nil {
+			return nil, err
+		}
+
+		envelope := c.buildSOAPEnvelope(string(bodyContent))
+
+		responseBody, err := c.sendSOAPRequest(endpoint, envelope)
+		if err != nil {
+			return nil, err
+		}
+
+		var response struct {
+			Results []filePropertyXML `xml:"Body>listMetadataResponse>result"`
+		}
+		if err := xml.Unmarshal(responseBody, &response); err != nil {
+			return nil, fmt.Errorf("failed to parse list metadata response: %w", err)
+		}
+
+		for _, result := range response.Results {
+			if result.FullName == "" {
+				continue
+			}
+			properties = append(properties, result.toFileProperty())
+		}
+	}
+
+	return properties, nil
+}
+
+// ListMetadataInFolders lists the folders of a folder-based type such as
+// Report, Dashboard, Document or EmailTemplate, followed by the components in
+// each folder, including the unfiled public folder where one exists
+func (c *Client) ListMetadataInFolders(metadataType string) ([]FileProperty, error) {
+	folderType, ok := metadataFolderTypes[metadataType]
+	if !ok {
+		return nil, fmt.Errorf("%s is not a folder-based metadata type", metadataType)
+	}
+
+	folders, err := c.ListMetadata(ListMetadataQuery{Type: folderType})
+	if err != nil {
+		return nil, err
+	}
+
+	var queries []ListMetadataQuery
+	if metadataType == "Report" || metadataType == "EmailTemplate" {
+		queries = append(queries, ListMetadataQuery{Type: metadataType, Folder: "unfiled$public"})
+	}
+	for _, folder := range folders {
+		queries = append(queries, ListMetadataQuery{Type: metadataType, Folder: folder.FullName})
+	}
+
+	components, err := c.ListMetadata(queries...)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(folders, components...), nil
+}
+
+// DescribeMetadata describes the metadata types available in the org
+func (c *Client) DescribeMetadata() (*DescribeMetadataResult, error) {
+	if c.AccessToken == "" || c.InstanceURL == "" {
+		return nil, errors.New("missing authentication details")
+	}
+
+	apiVersion := c.getMetadataAPIVersion()
+	endpoint := fmt.Sprintf("%s/services/Soap/m/%s", c.InstanceURL, apiVersion)
+
+	bodyContent := fmt.Sprintf(`<met:describeMetadata>
+      <met:asOfVersion>%s</met:asOfVersion>
+    </met:describeMetadata>`, apiVersion)
+
+	envelope := c.buildSOAPEnvelope(bodyContent)
+
+	responseBody, err := c.sendSOAPRequest(endpoint, envelope)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Result struct {
+			MetadataObjects []struct {
+				TypeName      string   `xml:"xmlName"`
+				ChildXMLNames []string `xml:"childXmlNames"`
+				DirectoryName string   `xml:"directoryName"`
+				InFolder      bool     `xml:"inFolder"`
+				MetaFile      bool     `xml:"metaFile"`
+				Suffix        string   `xml:"suffix"`
+			} `xml:"metadataObjects"`
+			OrganizationNamespace string `xml:"organizationNamespace"`
+			PartialSaveAllowed    bool   `xml:"partialSaveAllowed"`
+			TestRequired          bool   `xml:"testRequired"`
+		} `xml:"Body>describeMetadataResponse>result"`
+	}
+
+	if err := xml.Unmarshal(responseBody, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse describe metadata response: %w", err)
+	}
+
+	result := &DescribeMetadataResult{
+		OrganizationNamespace: response.Result.OrganizationNamespace,
+		PartialSaveAllowed:    response.Result.PartialSaveAllowed,
+		TestRequired:          response.Result.TestRequired,
+	}
+	for _, object := range response.Result.MetadataObjects {
+		result.MetadataObjects = append(result.MetadataObjects, DescribeMetadataObject(object))
+	}
+
+	return result, nil
+}
+
+// Object returns the description of a metadata type, or nil when it isn't available
+func (r *DescribeMetadataResult) Object(typeName string) *DescribeMetadataObject {
+	for i := range r.MetadataObjects {
+		if strings.EqualFold(r.MetadataObjects[i].TypeName, typeName) {
+			return &r.MetadataObjects[i]
+		}
+	}
+	return nil
+}
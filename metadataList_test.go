@@ -0,0 +1,127 @@
+package go_salesforce_api_client_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	go_salesforce_api_client "github.com/MASA-JAPAN/go-salesforce-api-client"
+)
+
+func TestListMetadata_Batches(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var batches [][]string
+	queryPattern := regexp.MustCompile(`<met:queries>(?:<met:folder>[^<]*</met:folder>)?<met:type>(\w+)</met:type></met:queries>`)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		body, _ := io.ReadAll(r.Body)
+		var types []string
+		var results strings.Builder
+		for _, match := range queryPattern.FindAllStringSubmatch(string(body), -1) {
+			types = append(types, match[1])
+			results.WriteString(`<result><fileName>x/` + match[1] + `</fileName><fullName>` + match[1] + `Item</fullName><type>` + match[1] + `</type></result>`)
+		}
+		batches = append(batches, types)
+		writeSOAPResponse(w, `<listMetadataResponse xmlns="http://soap.sforce.com/2006/04/metadata">`+results.String()+`</listMetadataResponse>`)
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	properties, err := client.ListMetadata(
+		go_salesforce_api_client.ListMetadataQuery{Type: "ApexClass"},
+		go_salesforce_api_client.ListMetadataQuery{Type: "ApexTrigger"},
+		go_salesforce_api_client.ListMetadataQuery{Type: "CustomObject"},
+		go_salesforce_api_client.ListMetadataQuery{Type: "Layout"},
+	)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(batches) != 2 || len(batches[0]) != 3 || len(batches[1]) != 1 {
+		t.Errorf("Expected batches of 3 and 1 queries, got: %v", batches)
+	}
+	if len(properties) != 4 || properties[3].FullName != "LayoutItem" || properties[3].Type != "Layout" {
+		t.Errorf("Unexpected properties: %+v", properties)
+	}
+}
+
+func TestListMetadataInFolders(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodyStr := string(body)
+		var results string
+		switch {
+		case strings.Contains(bodyStr, "<met:type>ReportFolder</met:type>"):
+			results = `<result><fullName>Sales</fullName><type>ReportFolder</type></result>`
+		case strings.Contains(bodyStr, "<met:folder>unfiled$public</met:folder>") && strings.Contains(bodyStr, "<met:folder>Sales</met:folder>"):
+			results = `<result><fullName>unfiled$public/Open_Cases</fullName><type>Report</type></result>
+				<result><fullName>Sales/Pipeline</fullName><type>Report</type></result>`
+		default:
+			t.Errorf("Unexpected request: %s", bodyStr)
+		}
+		writeSOAPResponse(w, `<listMetadataResponse xmlns="http://soap.sforce.com/2006/04/metadata">`+results+`</listMetadataResponse>`)
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	properties, err := client.ListMetadataInFolders("Report")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	var names []string
+	for _, p := range properties {
+		names = append(names, p.FullName)
+	}
+	if strings.Join(names, ",") != "Sales,unfiled$public/Open_Cases,Sales/Pipeline" {
+		t.Errorf("Unexpected components: %v", names)
+	}
+
+	if _, err := client.ListMetadataInFolders("ApexClass"); err == nil {
+		t.Error("Expected error for a type that isn't folder-based")
+	}
+}
+
+func TestDescribeMetadata(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeSOAPResponse(w, `<describeMetadataResponse xmlns="http://soap.sforce.com/2006/04/metadata"><result>
+			<metadataObjects><directoryName>classes</directoryName><inFolder>false</inFolder><metaFile>true</metaFile><suffix>cls</suffix><xmlName>ApexClass</xmlName></metadataObjects>
+			<metadataObjects><childXmlNames>CustomField</childXmlNames><childXmlNames>ValidationRule</childXmlNames><directoryName>objects</directoryName><inFolder>false</inFolder><metaFile>false</metaFile><suffix>object</suffix><xmlName>CustomObject</xmlName></metadataObjects>
+			<metadataObjects><directoryName>reports</directoryName><inFolder>true</inFolder><metaFile>false</metaFile><suffix>report</suffix><xmlName>Report</xmlName></metadataObjects>
+			<organizationNamespace></organizationNamespace><partialSaveAllowed>true</partialSaveAllowed><testRequired>false</testRequired>
+		</result></describeMetadataResponse>`)
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	result, err := client.DescribeMetadata()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(result.MetadataObjects) != 3 || !result.PartialSaveAllowed {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+	class := result.Object("ApexClass")
+	if class == nil || class.DirectoryName != "classes" || class.Suffix != "cls" || !class.MetaFile {
+		t.Errorf("Unexpected ApexClass description: %+v", class)
+	}
+	object := result.Object("CustomObject")
+	if object == nil || strings.Join(object.ChildXMLNames, ",") != "CustomField,ValidationRule" {
+		t.Errorf("Unexpected CustomObject description: %+v", object)
+	}
+	if report := result.Object("Report"); report == nil || !report.InFolder {
+		t.Errorf("Expected Report to be in folders: %+v", report)
+	}
+	if result.Object("Unknown") != nil {
+		t.Error("Expected nil for unknown type")
+	}
+}
@@ -0,0 +1,311 @@
+package go_salesforce_api_client
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Names of the manifest files at the root of a metadata package
+const (
+	packageXMLFileName             = "package.xml"
+	destructiveChangesFileName     = "destructiveChanges.xml"
+	destructiveChangesPreFileName  = "destructiveChangesPre.xml"
+	destructiveChangesPostFileName = "destructiveChangesPost.xml"
+	metadataNamespace              = "http://soap.sforce.com/2006/04/metadata"
+	metadataFileSuffix             = "-meta.xml"
+)
+
+// metadataDirectoryTypes maps the directories of a metadata-format source
+// tree to their metadata type
+var metadataDirectoryTypes = map[string]string{
+	"applications":        "CustomApplication",
+	"aura":                "AuraDefinitionBundle",
+	"classes":             "ApexClass",
+	"components":          "ApexComponent",
+	"contentassets":       "ContentAsset",
+	"customMetadata":      "CustomMetadata",
+	"customPermissions":   "CustomPermission",
+	"dashboards":          "Dashboard",
+	"documents":           "Document",
+	"email":               "EmailTemplate",
+	"flexipages":          "FlexiPage",
+	"flows":               "Flow",
+	"globalValueSets":     "GlobalValueSet",
+	"labels":              "CustomLabels",
+	"layouts":             "Layout",
+	"lwc":                 "LightningComponentBundle",
+	"namedCredentials":    "NamedCredential",
+	"objectTranslations":  "CustomObjectTranslation",
+	"objects":             "CustomObject",
+	"pages":               "ApexPage",
+	"permissionsetgroups": "PermissionSetGroup",
+	"permissionsets":      "PermissionSet",
+	"profiles":            "Profile",
+	"quickActions":        "QuickAction",
+	"remoteSiteSettings":  "RemoteSiteSetting",
+	"reports":             "Report",
+	"settings":            "Settings",
+	"staticresources":     "StaticResource",
+	"tabs":                "CustomTab",
+	"translations":        "Translations",
+	"triggers":            "ApexTrigger",
+	"workflows":           "Workflow",
+}
+
+// metadataBundleTypes are stored as a directory per component
+var metadataBundleTypes = map[string]bool{
+	"AuraDefinitionBundle":     true,
+	"LightningComponentBundle": true,
+}
+
+// MetadataPackageOptions configures BuildMetadataPackage
+type MetadataPackageOptions struct {
+	// GeneratePackageXML creates package.xml from the directory contents,
+	// replacing any existing one. Otherwise the existing package.xml is
+	// validated against the directory contents.
+	GeneratePackageXML bool
+	APIVersion         string // Version of a generated package.xml, defaults to the Metadata API version
+}
+
+// MetadataPackage represents a metadata-format source tree ready to be zipped and deployed
+type MetadataPackage struct {
+	fsys       fs.FS
+	files      []string // Paths of the metadata files in fsys
+	packageXML []byte
+	// Components maps each metadata type found in the source tree to its members
+	Components map[string][]string
+	// DestructiveChanges holds the names of the destructive changes manifests that are included
+	DestructiveChanges []string
+}
+
+// BuildMetadataPackageFromDir builds a package from a metadata-format directory
+func BuildMetadataPackageFromDir(dir string, options MetadataPackageOptions) (*MetadataPackage, error) {
+	return BuildMetadataPackage(os.DirFS(dir), options)
+}
+
+// BuildMetadataPackage builds a package from a metadata-format source tree,
+// with package.xml and any destructiveChanges manifests at its root. Hidden
+// files and Jest __tests__ directories are skipped, as the sf CLI does.
+func BuildMetadataPackage(fsys fs.FS, options MetadataPackageOptions) (*MetadataPackage, error) {
+	pkg := &MetadataPackage{fsys: fsys, Components: map[string][]string{}}
+	seen := map[string]map[string]bool{}
+	var unknown []string
+
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p != "." && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			if d.Name() == "__tests__" {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		switch p {
+		case packageXMLFileName:
+			return nil
+		case destructiveChangesFileName, destructiveChangesPreFileName, destructiveChangesPostFileName:
+			if err := validatePackageXMLFile(fsys, p); err != nil {
+				return err
+			}
+			pkg.DestructiveChanges = append(pkg.DestructiveChanges, p)
+			return nil
+		}
+
+		pkg.files = append(pkg.files, p)
+		metadataType, member, ok := metadataComponentForPath(p)
+		if !ok {
+			unknown = append(unknown, p)
+			return nil
+		}
+		if seen[metadataType] == nil {
+			seen[metadataType] = map[string]bool{}
+		}
+		if !seen[metadataType][member] {
+			seen[metadataType][member] = true
+			pkg.Components[metadataType] = append(pkg.Components[metadataType], member)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for metadataType := range pkg.Components {
+		sort.Strings(pkg.Components[metadataType])
+	}
+
+	if options.GeneratePackageXML {
+		if len(unknown) > 0 {
+			return nil, fmt.Errorf("%w: cannot infer the metadata type of %s", ErrInvalidManifest, strings.Join(unknown, ", "))
+		}
+		version := options.APIVersion
+		if version == "" {
+			version = defaultMetadataAPIVersion
+		}
+		manifest := NewPackageManifest(version)
+		for metadataType, members := range pkg.Components {
+			manifest.Add(metadataType, members...)
+		}
+		pkg.packageXML, err = manifest.MarshalPackageXML()
+		if err != nil {
+			return nil, err
+		}
+		return pkg, nil
+	}
+
+	pkg.packageXML, err = fs.ReadFile(fsys, packageXMLFileName)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidManifest, err)
+	}
+	if err := validatePackageXML(pkg.packageXML, pkg.Components); err != nil {
+		return nil, err
+	}
+
+	return pkg, nil
+}
+
+// metadataComponentForPath infers the metadata type and member name of a
+// file in a metadata-format source tree
+func metadataComponentForPath(p string) (string, string, bool) {
+	dir, rest, ok := strings.Cut(p, "/")
+	if !ok {
+		return "", "", false
+	}
+	metadataType, ok := metadataDirectoryTypes[dir]
+	if !ok {
+		return "", "", false
+	}
+
+	if metadataBundleTypes[metadataType] {
+		bundle, _, _ := strings.Cut(rest, "/")
+		return metadataType, bundle, true
+	}
+
+	member := strings.TrimSuffix(rest, metadataFileSuffix)
+	// Documents keep their file extension in the member name
+	if metadataType != "Document" || !strings.Contains(member, "/") {
+		member = strings.TrimSuffix(member, path.Ext(member))
+	}
+	return metadataType, member, true
+}
+
+// validatePackageXMLFile checks that a manifest in the source tree is well-formed
+func validatePackageXMLFile(fsys fs.FS, name string) error {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return err
+	}
+	if _, err := ParsePackageManifest(data); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// validatePackageXML checks that package.xml lists every component of the
+// source tree, either by name or with a wildcard
+func validatePackageXML(data []byte, components map[string][]string) error {
+	manifest, err := ParsePackageManifest(data)
+	if err != nil {
+		return fmt.Errorf("package.xml: %w", err)
+	}
+
+	var missing []string
+	for metadataType, members := range components {
+		for _, member := range members {
+			if !manifest.Contains(metadataType, member) {
+				missing = append(missing, metadataType+":"+member)
+			}
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("%w: package.xml doesn't list %s", ErrInvalidManifest, strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// PackageXML returns the package.xml included in the package
+func (p *MetadataPackage) PackageXML() []byte {
+	return p.packageXML
+}
+
+// WriteZip streams the package as a zip file to w
+func (p *MetadataPackage) WriteZip(w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	writeFile := func(name string, r io.Reader) error {
+		fw, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(fw, r)
+		return err
+	}
+
+	if err := writeFile(packageXMLFileName, bytes.NewReader(p.packageXML)); err != nil {
+		return err
+	}
+
+	names := append(append([]string(nil), p.DestructiveChanges...), p.files...)
+	for _, name := range names {
+		f, err := p.fsys.Open(name)
+		if err != nil {
+			return err
+		}
+		err = writeFile(name, f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// Zip returns the package as an in-memory zip file
+func (p *MetadataPackage) Zip() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := p.WriteZip(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Base64 returns the zipped package encoded as expected by DeployMetadata
+func (p *MetadataPackage) Base64() (string, error) {
+	var encoded strings.Builder
+	encoder := base64.NewEncoder(base64.StdEncoding, &encoded)
+	if err := p.WriteZip(encoder); err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+	return encoded.String(), nil
+}
+
+// DeployPackage zips a package and starts its deployment
+func (c *Client) DeployPackage(pkg *MetadataPackage, options MetadataDeployOptions) (*MetadataAsyncResult, error) {
+	zipFileBase64, err := pkg.Base64()
+	if err != nil {
+		return nil, err
+	}
+	return c.DeployMetadata(zipFileBase64, options)
+}
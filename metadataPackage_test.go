@@ -0,0 +1,177 @@
+package go_salesforce_api_client_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	go_salesforce_api_client "github.com/MASA-JAPAN/go-salesforce-api-client"
+)
+
+func newTestSourceTree() fstest.MapFS {
+	return fstest.MapFS{
+		"classes/AccountService.cls":              {Data: []byte("public class AccountService {}")},
+		"classes/AccountService.cls-meta.xml":     {Data: []byte("<ApexClass/>")},
+		"lwc/accountCard/accountCard.js":          {Data: []byte("export default class {}")},
+		"lwc/accountCard/accountCard.js-meta.xml": {Data: []byte("<LightningComponentBundle/>")},
+		"reports/Sales.reportFolder-meta.xml":     {Data: []byte("<ReportFolder/>")},
+		"reports/Sales/Pipeline.report":           {Data: []byte("<Report/>")},
+		"documents/Shared/logo.png":               {Data: []byte("png")},
+		"documents/Shared/logo.png-meta.xml":      {Data: []byte("<Document/>")},
+		"objects/Account.object":                  {Data: []byte("<CustomObject/>")},
+		"destructiveChangesPost.xml":              {Data: []byte(`<Package xmlns="http://soap.sforce.com/2006/04/metadata"><types><members>OldService</members><name>ApexClass</name></types></Package>`)},
+		".DS_Store":                               {Data: []byte("ignored")},
+		"classes/.hidden/Ignored.cls":             {Data: []byte("ignored")},
+		"lwc/accountCard/__tests__/card.test.js":  {Data: []byte("ignored")},
+	}
+}
+
+func TestBuildMetadataPackage_Generate(t *testing.T) {
+	t.Parallel()
+
+	pkg, err := go_salesforce_api_client.BuildMetadataPackage(newTestSourceTree(), go_salesforce_api_client.MetadataPackageOptions{
+		GeneratePackageXML: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	expected := map[string][]string{
+		"ApexClass":                {"AccountService"},
+		"CustomObject":             {"Account"},
+		"Document":                 {"Shared/logo.png"},
+		"LightningComponentBundle": {"accountCard"},
+		"Report":                   {"Sales", "Sales/Pipeline"},
+	}
+	for metadataType, members := range expected {
+		if strings.Join(pkg.Components[metadataType], ",") != strings.Join(members, ",") {
+			t.Errorf("Expected %s members %v, got %v", metadataType, members, pkg.Components[metadataType])
+		}
+	}
+	if len(pkg.Components) != len(expected) {
+		t.Errorf("Unexpected components: %v", pkg.Components)
+	}
+
+	packageXML := string(pkg.PackageXML())
+	if !strings.Contains(packageXML, `<Package xmlns="http://soap.sforce.com/2006/04/metadata">`) ||
+		!strings.Contains(packageXML, "<version>58.0</version>") {
+		t.Errorf("Unexpected package.xml: %s", packageXML)
+	}
+	names := regexp.MustCompile(`<name>(\w+)</name>`).FindAllStringSubmatch(packageXML, -1)
+	if len(names) != 5 || names[0][1] != "ApexClass" || names[4][1] != "Report" {
+		t.Errorf("Expected types sorted by name, got: %s", packageXML)
+	}
+
+	data, err := pkg.Zip()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("Invalid zip: %v", err)
+	}
+	var files []string
+	for _, f := range zr.File {
+		files = append(files, f.Name)
+	}
+	sort.Strings(files)
+	if len(files) != 11 || files[0] != "classes/AccountService.cls" || files[2] != "destructiveChangesPost.xml" {
+		t.Errorf("Unexpected zip entries: %v", files)
+	}
+}
+
+func TestBuildMetadataPackage_ValidateExisting(t *testing.T) {
+	t.Parallel()
+
+	tree := newTestSourceTree()
+	tree["package.xml"] = &fstest.MapFile{Data: []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<Package xmlns="http://soap.sforce.com/2006/04/metadata">
+    <types><members>*</members><name>ApexClass</name></types>
+    <types><members>accountCard</members><name>LightningComponentBundle</name></types>
+    <version>58.0</version>
+</Package>`)}
+
+	_, err := go_salesforce_api_client.BuildMetadataPackage(tree, go_salesforce_api_client.MetadataPackageOptions{})
+	if !errors.Is(err, go_salesforce_api_client.ErrInvalidManifest) {
+		t.Fatalf("Expected ErrInvalidManifest, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "CustomObject:Account") || strings.Contains(err.Error(), "ApexClass") {
+		t.Errorf("Expected only unlisted components in error, got: %v", err)
+	}
+
+	delete(tree, "objects/Account.object")
+	delete(tree, "reports/Sales.reportFolder-meta.xml")
+	delete(tree, "reports/Sales/Pipeline.report")
+	delete(tree, "documents/Shared/logo.png")
+	delete(tree, "documents/Shared/logo.png-meta.xml")
+	pkg, err := go_salesforce_api_client.BuildMetadataPackage(tree, go_salesforce_api_client.MetadataPackageOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !bytes.Equal(pkg.PackageXML(), tree["package.xml"].Data) {
+		t.Error("Expected the existing package.xml to be kept")
+	}
+}
+
+func TestBuildMetadataPackage_UnknownDirectory(t *testing.T) {
+	t.Parallel()
+
+	tree := fstest.MapFS{"unknownType/Thing.xyz": {Data: []byte("x")}}
+	_, err := go_salesforce_api_client.BuildMetadataPackage(tree, go_salesforce_api_client.MetadataPackageOptions{GeneratePackageXML: true})
+	if !errors.Is(err, go_salesforce_api_client.ErrInvalidManifest) || !strings.Contains(err.Error(), "unknownType/Thing.xyz") {
+		t.Errorf("Expected ErrInvalidManifest naming the file, got: %v", err)
+	}
+}
+
+func TestDeployPackage(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		match := regexp.MustCompile(`<met:ZipFile>([^<]+)</met:ZipFile>`).FindSubmatch(body)
+		if match == nil {
+			t.Fatalf("Expected ZipFile in request")
+		}
+		data, err := base64.StdEncoding.DecodeString(string(match[1]))
+		if err != nil {
+			t.Fatalf("Invalid base64: %v", err)
+		}
+		if _, err := zip.NewReader(bytes.NewReader(data), int64(len(data))); err != nil {
+			t.Errorf("Invalid zip: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "text/xml; charset=UTF-8")
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/">
+    <soapenv:Body>
+        <deployResponse xmlns="http://soap.sforce.com/2006/04/metadata">
+            <result><id>0Af1X00000XXXXXQAQ</id></result>
+        </deployResponse>
+    </soapenv:Body>
+</soapenv:Envelope>`))
+	}))
+	defer server.Close()
+
+	pkg, err := go_salesforce_api_client.BuildMetadataPackage(newTestSourceTree(), go_salesforce_api_client.MetadataPackageOptions{GeneratePackageXML: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	result, err := client.DeployPackage(pkg, go_salesforce_api_client.MetadataDeployOptions{SinglePackage: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.ID != "0Af1X00000XXXXXQAQ" {
+		t.Errorf("Unexpected deploy ID: %s", result.ID)
+	}
+}
@@ -0,0 +1,235 @@
+package go_salesforce_api_client
+
+import "encoding/xml"
+
+// The typed components below cover the commonly changed properties of their
+// metadata type. Fields are declared in the element order of the Metadata API
+// WSDL, which the CRUD calls require. Use MetadataXML for other properties.
+
+// MetadataCustomObject is a standard or custom object
+type MetadataCustomObject struct {
+	FullName             string                `xml:"fullName"`                   // e.g. Account or Invoice__c
+	DeploymentStatus     string                `xml:"deploymentStatus,omitempty"` // Deployed or InDevelopment
+	Description          string                `xml:"description,omitempty"`
+	EnableActivities     bool                  `xml:"enableActivities,omitempty"`
+	EnableBulkAPI        bool                  `xml:"enableBulkApi,omitempty"`
+	EnableFeeds          bool                  `xml:"enableFeeds,omitempty"`
+	EnableHistory        bool                  `xml:"enableHistory,omitempty"`
+	EnableReports        bool                  `xml:"enableReports,omitempty"`
+	EnableSearch         bool                  `xml:"enableSearch,omitempty"`
+	EnableSharing        bool                  `xml:"enableSharing,omitempty"`
+	EnableStreamingAPI   bool                  `xml:"enableStreamingApi,omitempty"`
+	ExternalSharingModel string                `xml:"externalSharingModel,omitempty"`
+	Fields               []MetadataCustomField `xml:"fields,omitempty"`
+	Label                string                `xml:"label,omitempty"`
+	NameField            *MetadataCustomField  `xml:"nameField,omitempty"`
+	PluralLabel          string                `xml:"pluralLabel,omitempty"`
+	SharingModel         string                `xml:"sharingModel,omitempty"` // e.g. ReadWrite or Private
+	Visibility           string                `xml:"visibility,omitempty"`
+}
+
+// MetadataType returns CustomObject
+func (MetadataCustomObject) MetadataType() string { return "CustomObject" }
+
+// MetadataFullName returns the API name of the object
+func (m MetadataCustomObject) MetadataFullName() string { return m.FullName }
+
+// MetadataCustomField is a field of an object. Its FullName includes the
+// object, e.g. Account.Region__c, except for fields nested in a MetadataCustomObject.
+type MetadataCustomField struct {
+	FullName             string               `xml:"fullName,omitempty"`     // Empty for the NameField of an object
+	DefaultValue         string               `xml:"defaultValue,omitempty"` // A formula expression, e.g. "false" or "'Open'"
+	DeleteConstraint     string               `xml:"deleteConstraint,omitempty"`
+	Description          string               `xml:"description,omitempty"`
+	DisplayFormat        string               `xml:"displayFormat,omitempty"` // AutoNumber fields, e.g. INV-{0000}
+	ExternalID           bool                 `xml:"externalId,omitempty"`
+	Formula              string               `xml:"formula,omitempty"`
+	FormulaTreatBlanksAs string               `xml:"formulaTreatBlanksAs,omitempty"`
+	InlineHelpText       string               `xml:"inlineHelpText,omitempty"`
+	Label                string               `xml:"label,omitempty"`
+	Length               int                  `xml:"length,omitempty"`
+	Precision            int                  `xml:"precision,omitempty"`
+	ReferenceTo          string               `xml:"referenceTo,omitempty"`
+	RelationshipLabel    string               `xml:"relationshipLabel,omitempty"`
+	RelationshipName     string               `xml:"relationshipName,omitempty"`
+	Required             bool                 `xml:"required,omitempty"`
+	Scale                int                  `xml:"scale,omitempty"`
+	TrackHistory         bool                 `xml:"trackHistory,omitempty"`
+	Type                 string               `xml:"type,omitempty"`
+	Unique               bool                 `xml:"unique,omitempty"`
+	ValueSet             *CustomFieldValueSet `xml:"valueSet,omitempty"`
+	VisibleLines         int                  `xml:"visibleLines,omitempty"`
+}
+
+// MetadataType returns CustomField
+func (MetadataCustomField) MetadataType() string { return "CustomField" }
+
+// MetadataFullName returns the full name of the field
+func (m MetadataCustomField) MetadataFullName() string { return m.FullName }
+
+// MarshalXML encodes the picklist value in the element order of the Metadata API
+func (v CustomFieldPicklistValue) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(struct {
+		FullName string `xml:"fullName"`
+		Default  bool   `xml:"default"`
+		Label    string `xml:"label,omitempty"`
+	}{v.FullName, v.Default, v.Label}, start)
+}
+
+// MetadataPermissionSet is a permission set
+type MetadataPermissionSet struct {
+	FullName                string                  `xml:"fullName"`
+	ApplicationVisibilities []ApplicationVisibility `xml:"applicationVisibilities,omitempty"`
+	ClassAccesses           []ApexClassAccess       `xml:"classAccesses,omitempty"`
+	Description             string                  `xml:"description,omitempty"`
+	FieldPermissions        []FieldPermissions      `xml:"fieldPermissions,omitempty"`
+	HasActivationRequired   bool                    `xml:"hasActivationRequired,omitempty"`
+	Label                   string                  `xml:"label,omitempty"`
+	License                 string                  `xml:"license,omitempty"`
+	ObjectPermissions       []ObjectPermissions     `xml:"objectPermissions,omitempty"`
+	PageAccesses            []ApexPageAccess        `xml:"pageAccesses,omitempty"`
+	RecordTypeVisibilities  []RecordTypeVisibility  `xml:"recordTypeVisibilities,omitempty"`
+	TabSettings             []TabVisibility         `xml:"tabSettings,omitempty"`
+	UserPermissions         []UserPermission        `xml:"userPermissions,omitempty"`
+}
+
+// MetadataType returns PermissionSet
+func (MetadataPermissionSet) MetadataType() string { return "PermissionSet" }
+
+// MetadataFullName returns the API name of the permission set
+func (m MetadataPermissionSet) MetadataFullName() string { return m.FullName }
+
+// MetadataProfile is a profile
+type MetadataProfile struct {
+	FullName                string                    `xml:"fullName"`
+	ApplicationVisibilities []ApplicationVisibility   `xml:"applicationVisibilities,omitempty"`
+	ClassAccesses           []ApexClassAccess         `xml:"classAccesses,omitempty"`
+	Custom                  bool                      `xml:"custom,omitempty"`
+	Description             string                    `xml:"description,omitempty"`
+	FieldPermissions        []FieldPermissions        `xml:"fieldPermissions,omitempty"`
+	LayoutAssignments       []ProfileLayoutAssignment `xml:"layoutAssignments,omitempty"`
+	ObjectPermissions       []ObjectPermissions       `xml:"objectPermissions,omitempty"`
+	PageAccesses            []ApexPageAccess          `xml:"pageAccesses,omitempty"`
+	RecordTypeVisibilities  []RecordTypeVisibility    `xml:"recordTypeVisibilities,omitempty"`
+	TabVisibilities         []TabVisibility           `xml:"tabVisibilities,omitempty"`
+	UserLicense             string                    `xml:"userLicense,omitempty"`
+	UserPermissions         []UserPermission          `xml:"userPermissions,omitempty"`
+}
+
+// MetadataType returns Profile
+func (MetadataProfile) MetadataType() string { return "Profile" }
+
+// MetadataFullName returns the name of the profile
+func (m MetadataProfile) MetadataFullName() string { return m.FullName }
+
+// ApplicationVisibility grants access to an app. Default only applies to profiles.
+type ApplicationVisibility struct {
+	Application string `xml:"application"`
+	Default     bool   `xml:"default,omitempty"`
+	Visible     bool   `xml:"visible"`
+}
+
+// ApexClassAccess grants access to an Apex class
+type ApexClassAccess struct {
+	ApexClass string `xml:"apexClass"`
+	Enabled   bool   `xml:"enabled"`
+}
+
+// ApexPageAccess grants access to a Visualforce page
+type ApexPageAccess struct {
+	ApexPage string `xml:"apexPage"`
+	Enabled  bool   `xml:"enabled"`
+}
+
+// FieldPermissions grants access to a field such as Account.Region__c
+type FieldPermissions struct {
+	Editable bool   `xml:"editable"`
+	Field    string `xml:"field"`
+	Readable bool   `xml:"readable"`
+}
+
+// ObjectPermissions grants access to an object
+type ObjectPermissions struct {
+	AllowCreate      bool   `xml:"allowCreate"`
+	AllowDelete      bool   `xml:"allowDelete"`
+	AllowEdit        bool   `xml:"allowEdit"`
+	AllowRead        bool   `xml:"allowRead"`
+	ModifyAllRecords bool   `xml:"modifyAllRecords"`
+	Object           string `xml:"object"`
+	ViewAllRecords   bool   `xml:"viewAllRecords"`
+}
+
+// RecordTypeVisibility grants access to a record type. Default only applies to profiles.
+type RecordTypeVisibility struct {
+	Default    bool   `xml:"default,omitempty"`
+	RecordType string `xml:"recordType"`
+	Visible    bool   `xml:"visible"`
+}
+
+// TabVisibility sets the visibility of a tab, e.g. DefaultOn, DefaultOff or Hidden
+// for profiles and Visible or Available for permission sets
+type TabVisibility struct {
+	Tab        string `xml:"tab"`
+	Visibility string `xml:"visibility"`
+}
+
+// UserPermission grants a system permission such as ApiEnabled
+type UserPermission struct {
+	Enabled bool   `xml:"enabled"`
+	Name    string `xml:"name"`
+}
+
+// ProfileLayoutAssignment assigns a page layout, optionally for a record type
+type ProfileLayoutAssignment struct {
+	Layout     string `xml:"layout"`
+	RecordType string `xml:"recordType,omitempty"`
+}
+
+// MetadataLayout is a page layout. Its FullName is the object and layout
+// name, e.g. Account-Account Layout.
+type MetadataLayout struct {
+	FullName       string            `xml:"fullName"`
+	ExcludeButtons []string          `xml:"excludeButtons,omitempty"`
+	LayoutSections []LayoutSection   `xml:"layoutSections,omitempty"`
+	RelatedLists   []RelatedListItem `xml:"relatedLists,omitempty"`
+}
+
+// MetadataType returns Layout
+func (MetadataLayout) MetadataType() string { return "Layout" }
+
+// MetadataFullName returns the full name of the layout
+func (m MetadataLayout) MetadataFullName() string { return m.FullName }
+
+// LayoutSection is a section of a page layout
+type LayoutSection struct {
+	CustomLabel   bool           `xml:"customLabel,omitempty"`
+	DetailHeading bool           `xml:"detailHeading,omitempty"`
+	EditHeading   bool           `xml:"editHeading,omitempty"`
+	Label         string         `xml:"label,omitempty"`
+	LayoutColumns []LayoutColumn `xml:"layoutColumns,omitempty"`
+	Style         string         `xml:"style"` // e.g. TwoColumnsTopToBottom or OneColumn
+}
+
+// LayoutColumn is a column of a layout section
+type LayoutColumn struct {
+	LayoutItems []LayoutItem `xml:"layoutItems,omitempty"`
+}
+
+// LayoutItem is a field, link or blank space in a layout column
+type LayoutItem struct {
+	Behavior   string `xml:"behavior,omitempty"` // Edit, Required or Readonly
+	CustomLink string `xml:"customLink,omitempty"`
+	EmptySpace bool   `xml:"emptySpace,omitempty"`
+	Field      string `xml:"field,omitempty"`
+	Page       string `xml:"page,omitempty"`
+}
+
+// RelatedListItem is a related list of a page layout
+type RelatedListItem struct {
+	CustomButtons  []string `xml:"customButtons,omitempty"`
+	ExcludeButtons []string `xml:"excludeButtons,omitempty"`
+	Fields         []string `xml:"fields,omitempty"`
+	RelatedList    string   `xml:"relatedList"`
+	SortField      string   `xml:"sortField,omitempty"`
+	SortOrder      string   `xml:"sortOrder,omitempty"`
+}
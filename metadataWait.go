@@ -0,0 +1,114 @@
+package go_salesforce_api_client
+
+import (
+	"context"
+	"fmt"
+)
+
+// DeployWaitOptions configures WaitForDeploy
+type DeployWaitOptions struct {
+	WaitOptions
+	// DetailsOnFinalPollOnly polls without includeDetails and requests the
+	// component and test results once the deployment is done
+	DetailsOnFinalPollOnly bool
+	// OnProgress is called after every poll with the number of components
+	// and tests processed so far and the current StateDetail
+	OnProgress func(status *MetadataDeployResult)
+}
+
+// RetrieveWaitOptions configures WaitForRetrieve
+type RetrieveWaitOptions struct {
+	WaitOptions
+	// ZipOnFinalPollOnly polls without includeZip and requests the zip file
+	// once the retrieval is done
+	ZipOnFinalPollOnly bool
+	// OnProgress is called after every poll
+	OnProgress func(status *MetadataRetrieveResult)
+}
+
+// WaitForDeploy polls a deployment until it is done. A deployment that
+// finishes unsuccessfully returns its final status together with ErrDeployFailed.
+// When the final request for details fails, the status polled so far is
+// returned with that error.
+func (c *Client) WaitForDeploy(ctx context.Context, asyncProcessID string, options DeployWaitOptions) (*MetadataDeployResult, error) {
+	var status *MetadataDeployResult
+	err := poll(ctx, options.WaitOptions, func() (bool, error) {
+		var err error
+		status, err = c.CheckDeployStatusWithDetails(asyncProcessID, !options.DetailsOnFinalPollOnly)
+		if err != nil {
+			return false, err
+		}
+		if options.OnProgress != nil {
+			options.OnProgress(status)
+		}
+		return status.Done, nil
+	})
+	if err != nil {
+		return status, err
+	}
+
+	if options.DetailsOnFinalPollOnly {
+		detailed, err := c.CheckDeployStatusWithDetails(asyncProcessID, true)
+		if err != nil {
+			return status, err
+		}
+		status = detailed
+	}
+
+	if !status.Success {
+		return status, fmt.Errorf("%w: %s", ErrDeployFailed, deployFailureSummary(status))
+	}
+
+	return status, nil
+}
+
+// deployFailureSummary describes why a deployment failed
+func deployFailureSummary(status *MetadataDeployResult) string {
+	if status.ErrorMessage != "" {
+		return fmt.Sprintf("%s: %s", status.Status, status.ErrorMessage)
+	}
+	if status.Details != nil && len(status.Details.ComponentFailures) > 0 {
+		failure := status.Details.ComponentFailures[0]
+		return fmt.Sprintf("%s: %d component errors, %d test errors, first: %s %s: %s",
+			status.Status, status.NumberComponentErrors, status.NumberTestErrors,
+			failure.ComponentType, failure.FullName, failure.Problem)
+	}
+	return fmt.Sprintf("%s: %d component errors, %d test errors",
+		status.Status, status.NumberComponentErrors, status.NumberTestErrors)
+}
+
+// WaitForRetrieve polls a retrieval until it is done. A retrieval that
+// finishes unsuccessfully returns its final status together with ErrRetrieveFailed.
+// When the final request for the zip file fails, the status polled so far is
+// returned with that error.
+func (c *Client) WaitForRetrieve(ctx context.Context, asyncProcessID string, options RetrieveWaitOptions) (*MetadataRetrieveResult, error) {
+	var status *MetadataRetrieveResult
+	err := poll(ctx, options.WaitOptions, func() (bool, error) {
+		var err error
+		status, err = c.CheckRetrieveStatusWithZip(asyncProcessID, !options.ZipOnFinalPollOnly)
+		if err != nil {
+			return false, err
+		}
+		if options.OnProgress != nil {
+			options.OnProgress(status)
+		}
+		return status.Done, nil
+	})
+	if err != nil {
+		return status, err
+	}
+
+	if !status.Success {
+		return status, fmt.Errorf("%w: %s: %s", ErrRetrieveFailed, status.Status, status.ErrorMessage)
+	}
+
+	if options.ZipOnFinalPollOnly {
+		withZip, err := c.CheckRetrieveStatusWithZip(asyncProcessID, true)
+		if err != nil {
+			return status, err
+		}
+		status = withZip
+	}
+
+	return status, nil
+}
@@ -0,0 +1,154 @@
+package go_salesforce_api_client_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	go_salesforce_api_client "github.com/MASA-JAPAN/go-salesforce-api-client"
+)
+
+func writeSOAPResponse(w http.ResponseWriter, content string) {
+	w.Header().Set("Content-Type", "text/xml; charset=UTF-8")
+	_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/">
+    <soapenv:Body>` + content + `</soapenv:Body>
+</soapenv:Envelope>`))
+}
+
+func TestWaitForDeploy_DetailsOnFinalPollOnly(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var includeDetails []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		body, _ := io.ReadAll(r.Body)
+		detailed := strings.Contains(string(body), "<met:includeDetails>true</met:includeDetails>")
+		includeDetails = append(includeDetails, strconv.FormatBool(detailed))
+
+		result := `<id>0Af1</id><done>false</done><status>InProgress</status><stateDetail>Running Test: AccountTest.testInsert</stateDetail>
+			<numberComponentsDeployed>2</numberComponentsDeployed><numberComponentsTotal>2</numberComponentsTotal>
+			<numberTestsCompleted>1</numberTestsCompleted><numberTestsTotal>4</numberTestsTotal>`
+		if len(includeDetails) > 1 {
+			result = `<id>0Af1</id><done>true</done><status>Failed</status><success>false</success>
+				<numberComponentsDeployed>2</numberComponentsDeployed><numberComponentsTotal>2</numberComponentsTotal>
+				<numberTestErrors>1</numberTestErrors><numberTestsCompleted>4</numberTestsCompleted><numberTestsTotal>4</numberTestsTotal>`
+			if detailed {
+				result += `<details><componentFailures><componentType>ApexClass</componentType><fullName>AccountService</fullName>
+					<problem>Invalid type</problem></componentFailures></details>`
+			}
+		}
+		writeSOAPResponse(w, `<checkDeployStatusResponse xmlns="http://soap.sforce.com/2006/04/metadata"><result>`+result+`</result></checkDeployStatusResponse>`)
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+
+	var progress []string
+	status, err := client.WaitForDeploy(context.Background(), "0Af1", go_salesforce_api_client.DeployWaitOptions{
+		WaitOptions:            go_salesforce_api_client.WaitOptions{PollInterval: time.Millisecond},
+		DetailsOnFinalPollOnly: true,
+		OnProgress: func(s *go_salesforce_api_client.MetadataDeployResult) {
+			progress = append(progress, s.StateDetail)
+		},
+	})
+	if !errors.Is(err, go_salesforce_api_client.ErrDeployFailed) {
+		t.Fatalf("Expected ErrDeployFailed, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "AccountService: Invalid type") {
+		t.Errorf("Expected first failure in error, got: %v", err)
+	}
+	if strings.Join(includeDetails, ",") != "false,false,true" {
+		t.Errorf("Expected details only on the final poll, got: %v", includeDetails)
+	}
+	if len(progress) != 2 || progress[0] != "Running Test: AccountTest.testInsert" {
+		t.Errorf("Unexpected progress: %v", progress)
+	}
+	if status.Details == nil || len(status.Details.ComponentFailures) != 1 {
+		t.Errorf("Expected details in final status, got: %+v", status)
+	}
+}
+
+func TestWaitForRetrieve(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var includeZip []bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		body, _ := io.ReadAll(r.Body)
+		withZip := strings.Contains(string(body), "<met:includeZip>true</met:includeZip>")
+		includeZip = append(includeZip, withZip)
+
+		result := `<id>09S1</id><done>false</done><status>InProgress</status>`
+		if len(includeZip) > 1 {
+			result = `<id>09S1</id><done>true</done><status>Succeeded</status><success>true</success>`
+			if withZip {
+				result += `<zipFile>UEsFBgAAAAAAAAAAAAAAAAAAAAAAAA==</zipFile>`
+			}
+		}
+		writeSOAPResponse(w, `<checkRetrieveStatusResponse xmlns="http://soap.sforce.com/2006/04/metadata"><result>`+result+`</result></checkRetrieveStatusResponse>`)
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+
+	status, err := client.WaitForRetrieve(context.Background(), "09S1", go_salesforce_api_client.RetrieveWaitOptions{
+		WaitOptions:        go_salesforce_api_client.WaitOptions{PollInterval: time.Millisecond},
+		ZipOnFinalPollOnly: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(includeZip) != 3 || includeZip[0] || includeZip[1] || !includeZip[2] {
+		t.Errorf("Expected zip only on the final poll, got: %v", includeZip)
+	}
+	if status.ZipFileBase64 == "" {
+		t.Error("Expected zip file in final status")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.WaitForRetrieve(ctx, "09S1", go_salesforce_api_client.RetrieveWaitOptions{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got: %v", err)
+	}
+}
+
+func TestWaitForDeploy_FinalDetailsFailureKeepsStatus(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if strings.Contains(string(body), "<met:includeDetails>true</met:includeDetails>") {
+			w.WriteHeader(http.StatusInternalServerError)
+			writeSOAPResponse(w, `<soapenv:Fault><faultcode>sf:UNKNOWN_EXCEPTION</faultcode><faultstring>An unexpected error occurred</faultstring></soapenv:Fault>`)
+			return
+		}
+		writeSOAPResponse(w, `<checkDeployStatusResponse xmlns="http://soap.sforce.com/2006/04/metadata"><result>
+			<id>0Af1</id><done>true</done><status>Succeeded</status><success>true</success>
+		</result></checkDeployStatusResponse>`)
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	status, err := client.WaitForDeploy(context.Background(), "0Af1", go_salesforce_api_client.DeployWaitOptions{
+		WaitOptions:            go_salesforce_api_client.WaitOptions{PollInterval: time.Millisecond},
+		DetailsOnFinalPollOnly: true,
+	})
+	if !errors.Is(err, go_salesforce_api_client.ErrSOAPFault) {
+		t.Fatalf("Expected ErrSOAPFault, got: %v", err)
+	}
+	if status == nil || status.ID != "0Af1" || !status.Done || !status.Success {
+		t.Errorf("Expected the completed status to be returned, got: %+v", status)
+	}
+}
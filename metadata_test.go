@@ -1,11 +1,14 @@
 package go_salesforce_api_client_test
 
 import (
+	"context"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	go_salesforce_api_client "github.com/MASA-JAPAN/go-salesforce-api-client"
 )
@@ -532,6 +535,48 @@ func TestCancelDeploy_Success(t *testing.T) {
 	}
 }
 
+func TestCancelDeploy_InProgress(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		soapResponse := `<?xml version="1.0" encoding="UTF-8"?>
+<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/">
+    <soapenv:Body>
+        <cancelDeployResponse xmlns="http://soap.sforce.com/2006/04/metadata">
+            <result>
+                <id>0Af1X00000XXXXXQAQ</id>
+                <done>false</done>
+            </result>
+        </cancelDeployResponse>
+    </soapenv:Body>
+</soapenv:Envelope>`
+
+		w.Header().Set("Content-Type", "text/xml; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(soapResponse))
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{
+		AccessToken: "test_token",
+		InstanceURL: server.URL,
+	}
+
+	result, err := client.CancelDeploy("0Af1X00000XXXXXQAQ")
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if result.Done {
+		t.Error("Expected Done to be false")
+	}
+
+	if result.State != "Canceling" {
+		t.Errorf("Expected State Canceling, got: %s", result.State)
+	}
+}
+
 func TestRetrieveMetadata_Success(t *testing.T) {
 	t.Parallel()
 
@@ -825,3 +870,131 @@ func TestCheckRetrieveStatus_WithMessages(t *testing.T) {
 		t.Errorf("Expected problem 'Type not found in target org', got: %s", result.Messages[0].Problem)
 	}
 }
+
+func TestDeployMetadata_RunSpecifiedTests(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodyStr := string(body)
+
+		first := strings.Index(bodyStr, "<met:runTests>AccountTest</met:runTests>")
+		second := strings.Index(bodyStr, "<met:runTests>Contact&amp;LeadTest</met:runTests>")
+		singlePackage := strings.Index(bodyStr, "<met:singlePackage>")
+		if first < 0 || second < first || singlePackage < second {
+			t.Errorf("Expected escaped runTests before singlePackage, got: %s", bodyStr)
+		}
+
+		w.Header().Set("Content-Type", "text/xml; charset=UTF-8")
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/">
+    <soapenv:Body>
+        <deployResponse xmlns="http://soap.sforce.com/2006/04/metadata">
+            <result><id>0Af1X00000XXXXXQAQ</id></result>
+        </deployResponse>
+    </soapenv:Body>
+</soapenv:Envelope>`))
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{
+		AccessToken: "test_token",
+		InstanceURL: server.URL,
+	}
+
+	_, err := client.DeployMetadata("UEsDBAo=", go_salesforce_api_client.MetadataDeployOptions{
+		CheckOnly: true,
+		TestLevel: "RunSpecifiedTests",
+		RunTests:  []string{"AccountTest", "Contact&LeadTest"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+}
+
+func TestDeployRecentValidation_Success(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(body), "<met:validationId>0Af1X00000VALIDQAQ</met:validationId>") {
+			t.Errorf("Expected validation ID in request, got: %s", body)
+		}
+
+		w.Header().Set("Content-Type", "text/xml; charset=UTF-8")
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/">
+    <soapenv:Body>
+        <deployRecentValidationResponse xmlns="http://soap.sforce.com/2006/04/metadata">
+            <result>0Af1X00000QUICKQAQ</result>
+        </deployRecentValidationResponse>
+    </soapenv:Body>
+</soapenv:Envelope>`))
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{
+		AccessToken: "test_token",
+		InstanceURL: server.URL,
+	}
+
+	result, err := client.DeployRecentValidation("0Af1X00000VALIDQAQ")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.ID != "0Af1X00000QUICKQAQ" || result.Done {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+}
+
+func TestCancelDeployAndWait(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	checks := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		body, _ := io.ReadAll(r.Body)
+
+		var response string
+		switch {
+		case strings.Contains(string(body), "met:cancelDeploy"):
+			response = `<cancelDeployResponse xmlns="http://soap.sforce.com/2006/04/metadata">
+                <result><id>0Af1X00000XXXXXQAQ</id><done>false</done></result>
+            </cancelDeployResponse>`
+		case strings.Contains(string(body), "met:checkDeployStatus"):
+			checks++
+			status, done := "Canceling", "false"
+			if checks > 1 {
+				status, done = "Canceled", "true"
+			}
+			response = `<checkDeployStatusResponse xmlns="http://soap.sforce.com/2006/04/metadata">
+                <result><id>0Af1X00000XXXXXQAQ</id><done>` + done + `</done><status>` + status + `</status></result>
+            </checkDeployStatusResponse>`
+		default:
+			t.Errorf("Unexpected request: %s", body)
+		}
+
+		w.Header().Set("Content-Type", "text/xml; charset=UTF-8")
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/">
+    <soapenv:Body>` + response + `</soapenv:Body>
+</soapenv:Envelope>`))
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{
+		AccessToken: "test_token",
+		InstanceURL: server.URL,
+	}
+
+	result, err := client.CancelDeployAndWait(context.Background(), "0Af1X00000XXXXXQAQ",
+		go_salesforce_api_client.WaitOptions{PollInterval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !result.Done || result.Status != "Canceled" || checks != 2 {
+		t.Errorf("Expected Canceled after 2 checks, got %+v after %d checks", result, checks)
+	}
+}
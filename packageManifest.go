@@ -0,0 +1,200 @@
+package go_salesforce_api_client
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PackageManifestWildcard matches all components of a type in package.xml.
+// Folder-based types such as Report, Dashboard, Document and EmailTemplate
+// don't support it.
+const PackageManifestWildcard = "*"
+
+// PackageManifest represents a package.xml manifest
+type PackageManifest struct {
+	Types   []PackageTypeMembers
+	Version string
+}
+
+// PackageTypeMembers lists the members of a metadata type in a manifest
+type PackageTypeMembers struct {
+	Members []string `xml:"members"`
+	Name    string   `xml:"name"`
+}
+
+// packageManifestXML is the package.xml document representation of a manifest
+type packageManifestXML struct {
+	XMLName xml.Name             `xml:"Package"`
+	Xmlns   string               `xml:"xmlns,attr,omitempty"`
+	Types   []PackageTypeMembers `xml:"types"`
+	Version string               `xml:"version,omitempty"`
+}
+
+// unpackagedXML is the representation of a manifest inside a SOAP request
+type unpackagedXML struct {
+	XMLName xml.Name             `xml:"met:unpackaged"`
+	Types   []unpackagedTypesXML `xml:"met:types"`
+	Version string               `xml:"met:version,omitempty"`
+}
+
+// unpackagedTypesXML is the representation of manifest types inside a SOAP request
+type unpackagedTypesXML struct {
+	Members []string `xml:"met:members"`
+	Name    string   `xml:"met:name"`
+}
+
+// NewPackageManifest creates an empty manifest for the given API version
+func NewPackageManifest(version string) *PackageManifest {
+	return &PackageManifest{Version: version}
+}
+
+// ParsePackageManifest parses the content of a package.xml file
+func ParsePackageManifest(data []byte) (*PackageManifest, error) {
+	var doc packageManifestXML
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidManifest, err)
+	}
+
+	manifest := &PackageManifest{Version: strings.TrimSpace(doc.Version)}
+	for _, types := range doc.Types {
+		name := strings.TrimSpace(types.Name)
+		if name == "" {
+			return nil, fmt.Errorf("%w: types without a name", ErrInvalidManifest)
+		}
+		members := make([]string, 0, len(types.Members))
+		for _, member := range types.Members {
+			members = append(members, strings.TrimSpace(member))
+		}
+		manifest.Add(name, members...)
+	}
+
+	return manifest, nil
+}
+
+// MarshalPackageXML encodes the manifest as a package.xml file
+func (m *PackageManifest) MarshalPackageXML() ([]byte, error) {
+	data, err := xml.MarshalIndent(packageManifestXML{
+		Xmlns:   metadataNamespace,
+		Types:   m.sortedTypes(),
+		Version: m.Version,
+	}, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(data, '\n')...), nil
+}
+
+// unpackagedXML encodes the manifest as the unpackaged element of a retrieve request
+func (m *PackageManifest) unpackagedXML() (string, error) {
+	doc := unpackagedXML{Version: m.Version}
+	for _, types := range m.sortedTypes() {
+		doc.Types = append(doc.Types, unpackagedTypesXML(types))
+	}
+	data, err := xml.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// sortedTypes returns the types sorted by name with sorted members
+func (m *PackageManifest) sortedTypes() []PackageTypeMembers {
+	types := make([]PackageTypeMembers, 0, len(m.Types))
+	for _, t := range m.Types {
+		members := append([]string(nil), t.Members...)
+		sort.Strings(members)
+		types = append(types, PackageTypeMembers{Members: members, Name: t.Name})
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].Name < types[j].Name })
+	return types
+}
+
+// Add adds members of a metadata type, skipping members already listed
+func (m *PackageManifest) Add(typeName string, members ...string) {
+	for i := range m.Types {
+		if m.Types[i].Name != typeName {
+			continue
+		}
+		for _, member := range members {
+			if !containsString(m.Types[i].Members, member) {
+				m.Types[i].Members = append(m.Types[i].Members, member)
+			}
+		}
+		return
+	}
+
+	types := PackageTypeMembers{Name: typeName}
+	for _, member := range members {
+		if !containsString(types.Members, member) {
+			types.Members = append(types.Members, member)
+		}
+	}
+	m.Types = append(m.Types, types)
+}
+
+// Merge adds all members of other to the manifest and keeps the higher API version
+func (m *PackageManifest) Merge(other *PackageManifest) {
+	if other == nil {
+		return
+	}
+	for _, types := range other.Types {
+		m.Add(types.Name, types.Members...)
+	}
+	if compareAPIVersions(other.Version, m.Version) > 0 {
+		m.Version = other.Version
+	}
+}
+
+// Members returns the members listed for a metadata type
+func (m *PackageManifest) Members(typeName string) []string {
+	for _, types := range m.Types {
+		if types.Name == typeName {
+			return types.Members
+		}
+	}
+	return nil
+}
+
+// HasWildcard reports whether all components of a metadata type are requested
+func (m *PackageManifest) HasWildcard(typeName string) bool {
+	return containsString(m.Members(typeName), PackageManifestWildcard)
+}
+
+// Contains reports whether a component is listed by name or matched by a wildcard
+func (m *PackageManifest) Contains(typeName, member string) bool {
+	members := m.Members(typeName)
+	return containsString(members, member) || containsString(members, PackageManifestWildcard)
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// compareAPIVersions compares API versions such as 58.0 numerically. An empty
+// or invalid version is lower than any valid one.
+func compareAPIVersions(a, b string) int {
+	va, errA := strconv.ParseFloat(a, 64)
+	vb, errB := strconv.ParseFloat(b, 64)
+	switch {
+	case errA != nil && errB != nil:
+		return 0
+	case errA != nil:
+		return -1
+	case errB != nil:
+		return 1
+	case va < vb:
+		return -1
+	case va > vb:
+		return 1
+	}
+	return 0
+}
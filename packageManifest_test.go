@@ -0,0 +1,141 @@
+package go_salesforce_api_client_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	go_salesforce_api_client "github.com/MASA-JAPAN/go-salesforce-api-client"
+)
+
+const testPackageXML = `<?xml version="1.0" encoding="UTF-8"?>
+<!-- Release 42 -->
+<Package xmlns="http://soap.sforce.com/2006/04/metadata" fullName="release">
+    <types>
+        <members>Contact</members>
+        <members>Account</members>
+        <name>CustomObject</name>
+    </types>
+    <types>
+        <!-- every class -->
+        <members>*</members>
+        <name>ApexClass</name>
+    </types>
+    <version>57.0</version>
+</Package>`
+
+func TestParsePackageManifest(t *testing.T) {
+	t.Parallel()
+
+	manifest, err := go_salesforce_api_client.ParsePackageManifest([]byte(testPackageXML))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if manifest.Version != "57.0" || len(manifest.Types) != 2 {
+		t.Errorf("Unexpected manifest: %+v", manifest)
+	}
+	if !manifest.HasWildcard("ApexClass") || !manifest.Contains("ApexClass", "AnyClass") {
+		t.Error("Expected ApexClass wildcard to match any class")
+	}
+	if !manifest.Contains("CustomObject", "Account") || manifest.Contains("CustomObject", "Lead") {
+		t.Error("Expected only listed objects to be contained")
+	}
+
+	if _, err := go_salesforce_api_client.ParsePackageManifest([]byte("<Package><types>")); !errors.Is(err, go_salesforce_api_client.ErrInvalidManifest) {
+		t.Errorf("Expected ErrInvalidManifest, got: %v", err)
+	}
+	if _, err := go_salesforce_api_client.ParsePackageManifest([]byte("<Package><types><members>A</members></types></Package>")); !errors.Is(err, go_salesforce_api_client.ErrInvalidManifest) {
+		t.Errorf("Expected ErrInvalidManifest for types without name, got: %v", err)
+	}
+}
+
+func TestPackageManifest_MergeAndMarshal(t *testing.T) {
+	t.Parallel()
+
+	manifest := go_salesforce_api_client.NewPackageManifest("57.0")
+	manifest.Add("CustomObject", "Account")
+
+	other := go_salesforce_api_client.NewPackageManifest("58.0")
+	other.Add("CustomObject", "Account", "Contact")
+	other.Add("ApexTrigger", "AccountTrigger")
+	manifest.Merge(other)
+
+	if manifest.Version != "58.0" {
+		t.Errorf("Expected higher version to win, got: %s", manifest.Version)
+	}
+	if got := strings.Join(manifest.Members("CustomObject"), ","); got != "Account,Contact" {
+		t.Errorf("Expected deduplicated members, got: %s", got)
+	}
+
+	data, err := manifest.MarshalPackageXML()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<Package xmlns="http://soap.sforce.com/2006/04/metadata">
+    <types>
+        <members>AccountTrigger</members>
+        <name>ApexTrigger</name>
+    </types>
+    <types>
+        <members>Account</members>
+        <members>Contact</members>
+        <name>CustomObject</name>
+    </types>
+    <version>58.0</version>
+</Package>
+`
+	if string(data) != expected {
+		t.Errorf("Unexpected package.xml:\n%s", data)
+	}
+
+	roundTrip, err := go_salesforce_api_client.ParsePackageManifest(data)
+	if err != nil || len(roundTrip.Types) != 2 || roundTrip.Version != "58.0" {
+		t.Errorf("Round trip failed: %+v, %v", roundTrip, err)
+	}
+}
+
+func TestRetrieveMetadata_EncodesManifest(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodyStr := string(body)
+
+		expected := `<met:unpackaged><met:types><met:members>*</met:members><met:name>ApexClass</met:name></met:types>` +
+			`<met:types><met:members>Account</met:members><met:members>Contact</met:members><met:name>CustomObject</met:name></met:types>` +
+			`<met:version>57.0</met:version></met:unpackaged>`
+		if !strings.Contains(bodyStr, expected) {
+			t.Errorf("Expected encoded manifest, got: %s", bodyStr)
+		}
+		if strings.Contains(bodyStr, "Release 42") || strings.Contains(bodyStr, "fullName") {
+			t.Errorf("Expected comments and attributes to be dropped, got: %s", bodyStr)
+		}
+		if !strings.Contains(bodyStr, "<met:specificFiles>classes/A&amp;B.cls</met:specificFiles>") {
+			t.Errorf("Expected escaped specific files, got: %s", bodyStr)
+		}
+
+		w.Header().Set("Content-Type", "text/xml; charset=UTF-8")
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/">
+    <soapenv:Body>
+        <retrieveResponse xmlns="http://soap.sforce.com/2006/04/metadata">
+            <result><id>09S1X00000XXXXXQAQ</id></result>
+        </retrieveResponse>
+    </soapenv:Body>
+</soapenv:Envelope>`))
+	}))
+	defer server.Close()
+
+	client := &go_salesforce_api_client.Client{AccessToken: "test_token", InstanceURL: server.URL}
+	_, err := client.RetrieveMetadata(go_salesforce_api_client.MetadataRetrieveOptions{
+		UnpackageManifest: testPackageXML,
+		SpecificFiles:     []string{"classes/A&B.cls"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
 // QueryResponse represents the response structure from Salesforce SOQL query
@@ -55,3 +56,11 @@ func (c *Client) Query(soql string) (*QueryResponse, error) {
 
 	return &queryResp, nil
 }
+
+// soqlStringEscaper escapes characters with a special meaning in SOQL string literals
+var soqlStringEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+
+// escapeSOQLString escapes a value for use inside a quoted SOQL string literal
+func escapeSOQLString(value string) string {
+	return soqlStringEscaper.Replace(value)
+}
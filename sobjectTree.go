@@ -0,0 +1,347 @@
+package go_salesforce_api_client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SObjectTreeRecord represents a record of an sObject tree request.
+// Children maps a child relationship name (e.g. "Contacts") to nested records.
+type SObjectTreeRecord struct {
+	Type        string
+	ReferenceID string
+	Fields      map[string]any
+	Children    map[string][]SObjectTreeRecord
+}
+
+// SObjectTreeResultItem represents the outcome for a single reference ID
+type SObjectTreeResultItem struct {
+	ReferenceID string           `json:"referenceId"`
+	ID          string           `json:"id,omitempty"`
+	Errors      []CompositeError `json:"errors,omitempty"`
+}
+
+// SObjectTreeResult represents the response of the sObject Tree API
+type SObjectTreeResult struct {
+	HasErrors bool                    `json:"hasErrors"`
+	Results   []SObjectTreeResultItem `json:"results"`
+}
+
+// SObjectTreePlanEntry represents a step of an `sf data export tree --plan` file
+type SObjectTreePlanEntry struct {
+	SObject     string   `json:"sobject"`
+	SaveRefs    bool     `json:"saveRefs"`
+	ResolveRefs bool     `json:"resolveRefs"`
+	Files       []string `json:"files"`
+}
+
+// SObjectTreePlan represents a data plan loaded from disk
+type SObjectTreePlan struct {
+	Dir     string
+	Entries []SObjectTreePlanEntry
+}
+
+// sObjectTreeFile represents the record file format used by the sObject Tree API and plan files
+type sObjectTreeFile struct {
+	Records []SObjectTreeRecord `json:"records"`
+}
+
+const (
+	// maxSObjectTreeRecords is the number of records allowed in a single tree request
+	maxSObjectTreeRecords = 200
+	// maxSObjectTreeDepth is the number of levels allowed in a single tree request
+	maxSObjectTreeDepth = 5
+)
+
+// ErrSObjectTreeFailed is returned when the sObject Tree API rejects the request
+var ErrSObjectTreeFailed = errors.New("sObject tree creation failed")
+
+// MarshalJSON encodes the record in the sObject Tree API format
+func (r SObjectTreeRecord) MarshalJSON() ([]byte, error) {
+	data := make(map[string]any, len(r.Fields)+len(r.Children)+1)
+	for name, value := range r.Fields {
+		data[name] = value
+	}
+	for relationship, children := range r.Children {
+		data[relationship] = sObjectTreeFile{Records: children}
+	}
+	data["attributes"] = map[string]string{
+		"type":        r.Type,
+		"referenceId": r.ReferenceID,
+	}
+	return json.Marshal(data)
+}
+
+// UnmarshalJSON decodes a record in the sObject Tree API format
+func (r *SObjectTreeRecord) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*r = SObjectTreeRecord{Fields: make(map[string]any)}
+	for name, value := range raw {
+		if name == "attributes" {
+			var attributes struct {
+				Type        string `json:"type"`
+				ReferenceID string `json:"referenceId"`
+			}
+			if err := json.Unmarshal(value, &attributes); err != nil {
+				return err
+			}
+			r.Type = attributes.Type
+			r.ReferenceID = attributes.ReferenceID
+			continue
+		}
+
+		// Nested child records are objects with a "records" array
+		if bytes.HasPrefix(bytes.TrimSpace(value), []byte("{")) {
+			var children sObjectTreeFile
+			if err := json.Unmarshal(value, &children); err == nil && children.Records != nil {
+				if r.Children == nil {
+					r.Children = make(map[string][]SObjectTreeRecord)
+				}
+				r.Children[name] = children.Records
+				continue
+			}
+		}
+
+		var field any
+		if err := json.Unmarshal(value, &field); err != nil {
+			return err
+		}
+		r.Fields[name] = field
+	}
+
+	return nil
+}
+
+// IDs returns the created record IDs keyed by reference ID
+func (r *SObjectTreeResult) IDs() map[string]string {
+	ids := make(map[string]string, len(r.Results))
+	for _, item := range r.Results {
+		if item.ID != "" {
+			ids[item.ReferenceID] = item.ID
+		}
+	}
+	return ids
+}
+
+// countSObjectTreeRecords returns the total number of records and the depth of a tree
+func countSObjectTreeRecords(records []SObjectTreeRecord) (int, int) {
+	count, depth := 0, 0
+	for _, record := range records {
+		count++
+		childDepth := 0
+		for _, children := range record.Children {
+			n, d := countSObjectTreeRecords(children)
+			count += n
+			if d > childDepth {
+				childDepth = d
+			}
+		}
+		if childDepth+1 > depth {
+			depth = childDepth + 1
+		}
+	}
+	return count, depth
+}
+
+// CreateSObjectTree creates up to 200 records of the given type together with
+// their nested child records in a single request. When Salesforce rejects the
+// tree, the parsed result is returned along with ErrSObjectTreeFailed.
+func (c *Client) CreateSObjectTree(objectType string, records []SObjectTreeRecord) (*SObjectTreeResult, error) {
+	if c.AccessToken == "" || c.InstanceURL == "" {
+		return nil, errors.New("missing authentication details")
+	}
+
+	count, depth := countSObjectTreeRecords(records)
+	if count == 0 {
+		return nil, errors.New("sObject tree has no records")
+	}
+	if count > maxSObjectTreeRecords {
+		return nil, fmt.Errorf("sObject tree has %d records, maximum is %d", count, maxSObjectTreeRecords)
+	}
+	if depth > maxSObjectTreeDepth {
+		return nil, fmt.Errorf("sObject tree is %d levels deep, maximum is %d", depth, maxSObjectTreeDepth)
+	}
+
+	url := fmt.Sprintf("%s/services/data/v58.0/composite/tree/%s", c.InstanceURL, objectType)
+
+	// Copy the records so filling in the type doesn't modify the caller's slice
+	records = append([]SObjectTreeRecord(nil), records...)
+	for i := range records {
+		if records[i].Type == "" {
+			records[i].Type = objectType
+		}
+	}
+
+	jsonData, err := json.Marshal(sObjectTreeFile{Records: records})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.AccessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		var result SObjectTreeResult
+		if resp.StatusCode == http.StatusBadRequest && json.Unmarshal(body, &result) == nil && result.HasErrors {
+			return &result, fmt.Errorf("%w: %s", ErrSObjectTreeFailed, result.firstError())
+		}
+		return nil, fmt.Errorf("failed to create sObject tree, status: %d, response: %s", resp.StatusCode, string(body))
+	}
+
+	var result SObjectTreeResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// firstError describes the first error reported in the result
+func (r *SObjectTreeResult) firstError() string {
+	for _, item := range r.Results {
+		if len(item.Errors) > 0 {
+			return fmt.Sprintf("[%s] %s: %s", item.ReferenceID, item.Errors[0].ErrorCode, item.Errors[0].Message)
+		}
+	}
+	return "unknown error"
+}
+
+// LoadSObjectTreePlan loads a plan file created by `sf data export tree --plan`
+func LoadSObjectTreePlan(path string) (*SObjectTreePlan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []SObjectTreePlanEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse plan file %s: %w", path, err)
+	}
+
+	return &SObjectTreePlan{
+		Dir:     filepath.Dir(path),
+		Entries: entries,
+	}, nil
+}
+
+// LoadSObjectTreeFile loads a record file in the sObject Tree API format
+func LoadSObjectTreeFile(path string) ([]SObjectTreeRecord, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file sObjectTreeFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse record file %s: %w", path, err)
+	}
+
+	return file.Records, nil
+}
+
+// ImportSObjectTreePlan creates the records of every plan step in order.
+// Reference IDs of steps with saveRefs are remembered, and "@ReferenceId"
+// field values of steps with resolveRefs are replaced with the created IDs;
+// values that don't name a remembered reference are sent unchanged.
+// Files larger than the 200 record limit are split across several requests.
+// It returns the created record IDs keyed by reference ID.
+func (c *Client) ImportSObjectTreePlan(plan *SObjectTreePlan) (map[string]string, error) {
+	if plan == nil {
+		return nil, errors.New("sObject tree plan is nil")
+	}
+
+	refs := make(map[string]string)
+	for _, entry := range plan.Entries {
+		for _, file := range entry.Files {
+			records, err := LoadSObjectTreeFile(filepath.Join(plan.Dir, file))
+			if err != nil {
+				return refs, err
+			}
+
+			if entry.ResolveRefs {
+				resolveSObjectTreeRefs(records, refs)
+			}
+
+			for _, chunk := range chunkSObjectTreeRecords(records) {
+				result, err := c.CreateSObjectTree(entry.SObject, chunk)
+				if err != nil {
+					return refs, fmt.Errorf("%s: %w", file, err)
+				}
+				if entry.SaveRefs {
+					for ref, id := range result.IDs() {
+						refs[ref] = id
+					}
+				}
+			}
+		}
+	}
+
+	return refs, nil
+}
+
+// resolveSObjectTreeRefs replaces "@ReferenceId" field values with the IDs
+// saved by earlier plan steps. Other values starting with "@", such as social
+// media handles, are left unchanged.
+func resolveSObjectTreeRefs(records []SObjectTreeRecord, refs map[string]string) {
+	for _, record := range records {
+		for name, value := range record.Fields {
+			s, ok := value.(string)
+			if !ok || !strings.HasPrefix(s, "@") {
+				continue
+			}
+			if id, ok := refs[strings.TrimPrefix(s, "@")]; ok {
+				record.Fields[name] = id
+			}
+		}
+		for _, children := range record.Children {
+			resolveSObjectTreeRefs(children, refs)
+		}
+	}
+}
+
+// chunkSObjectTreeRecords splits top-level records so each chunk stays within the record limit
+func chunkSObjectTreeRecords(records []SObjectTreeRecord) [][]SObjectTreeRecord {
+	var chunks [][]SObjectTreeRecord
+	var current []SObjectTreeRecord
+	size := 0
+	for _, record := range records {
+		n, _ := countSObjectTreeRecords([]SObjectTreeRecord{record})
+		if size+n > maxSObjectTreeRecords && len(current) > 0 {
+			chunks = append(chunks, current)
+			current, size = nil, 0
+		}
+		current = append(current, record)
+		size += n
+	}
+	if len(current) > 0 {
+		chunks = append(chunks, current)
+	}
+	return chunks
+}
@@ -0,0 +1,166 @@
+package go_salesforce_api_client
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSObjectTreeRecord_JSONRoundTrip(t *testing.T) {
+	t.Parallel()
+	input := `{"attributes":{"type":"Account","referenceId":"AccountRef1"},"Name":"Acme",
+		"Contacts":{"records":[{"attributes":{"type":"Contact","referenceId":"ContactRef1"},"LastName":"Smith"}]}}`
+
+	var record SObjectTreeRecord
+	if err := json.Unmarshal([]byte(input), &record); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if record.Type != "Account" || record.ReferenceID != "AccountRef1" {
+		t.Errorf("Unexpected attributes: %s %s", record.Type, record.ReferenceID)
+	}
+	if record.Fields["Name"] != "Acme" {
+		t.Errorf("Expected Name Acme, got %v", record.Fields["Name"])
+	}
+	if len(record.Children["Contacts"]) != 1 || record.Children["Contacts"][0].ReferenceID != "ContactRef1" {
+		t.Fatalf("Unexpected children: %+v", record.Children)
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(string(data), `"Contacts":{"records":[`) {
+		t.Errorf("Expected nested records in %s", data)
+	}
+}
+
+func TestCreateSObjectTree(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/data/v58.0/composite/tree/Account" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"hasErrors":false,"results":[{"referenceId":"AccountRef1","id":"001000000000001"},{"referenceId":"ContactRef1","id":"003000000000001"}]}`))
+	}))
+	defer server.Close()
+
+	client := &Client{AccessToken: "mock_token", InstanceURL: server.URL}
+	records := []SObjectTreeRecord{{
+		ReferenceID: "AccountRef1",
+		Fields:      map[string]any{"Name": "Acme"},
+		Children: map[string][]SObjectTreeRecord{
+			"Contacts": {{Type: "Contact", ReferenceID: "ContactRef1", Fields: map[string]any{"LastName": "Smith"}}},
+		},
+	}}
+
+	result, err := client.CreateSObjectTree("Account", records)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if id := result.IDs()["ContactRef1"]; id != "003000000000001" {
+		t.Errorf("Expected contact ID 003000000000001, got %s", id)
+	}
+	if records[0].Type != "" {
+		t.Errorf("Expected the caller's records to be left unchanged, got type %q", records[0].Type)
+	}
+}
+
+func TestCreateSObjectTree_Errors(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"hasErrors":true,"results":[{"referenceId":"AccountRef1","errors":[{"statusCode":"INVALID_EMAIL_ADDRESS","errorCode":"INVALID_EMAIL_ADDRESS","message":"Email: invalid email address: 123","fields":["Email"]}]}]}`))
+	}))
+	defer server.Close()
+
+	client := &Client{AccessToken: "mock_token", InstanceURL: server.URL}
+	records := []SObjectTreeRecord{{ReferenceID: "AccountRef1", Fields: map[string]any{"Name": "Acme"}}}
+
+	result, err := client.CreateSObjectTree("Account", records)
+	if !errors.Is(err, ErrSObjectTreeFailed) {
+		t.Fatalf("Expected ErrSObjectTreeFailed, got %v", err)
+	}
+	if result == nil || !result.HasErrors || result.Results[0].Errors[0].ErrorCode != "INVALID_EMAIL_ADDRESS" {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+}
+
+func TestImportSObjectTreePlan(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	files := map[string]string{
+		"plan.json": `[
+			{"sobject":"Account","saveRefs":true,"resolveRefs":false,"files":["Account.json"]},
+			{"sobject":"Opportunity","saveRefs":false,"resolveRefs":true,"files":["Opportunity.json"]}
+		]`,
+		"Account.json":     `{"records":[{"attributes":{"type":"Account","referenceId":"AccountRef1"},"Name":"Acme"}]}`,
+		"Opportunity.json": `{"records":[{"attributes":{"type":"Opportunity","referenceId":"OppRef1"},"Name":"Deal","AccountId":"@AccountRef1","Twitter__c":"@acme"}]}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Records []SObjectTreeRecord `json:"records"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("Failed to decode request: %s", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+		switch r.URL.Path {
+		case "/services/data/v58.0/composite/tree/Account":
+			_, _ = w.Write([]byte(`{"hasErrors":false,"results":[{"referenceId":"AccountRef1","id":"001000000000001"}]}`))
+		case "/services/data/v58.0/composite/tree/Opportunity":
+			if got := payload.Records[0].Fields["AccountId"]; got != "001000000000001" {
+				t.Errorf("Expected resolved AccountId, got %v", got)
+			}
+			if got := payload.Records[0].Fields["Twitter__c"]; got != "@acme" {
+				t.Errorf("Expected unknown reference to be left unchanged, got %v", got)
+			}
+			_, _ = w.Write([]byte(`{"hasErrors":false,"results":[{"referenceId":"OppRef1","id":"006000000000001"}]}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	plan, err := LoadSObjectTreePlan(filepath.Join(dir, "plan.json"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	client := &Client{AccessToken: "mock_token", InstanceURL: server.URL}
+	refs, err := client.ImportSObjectTreePlan(plan)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if refs["AccountRef1"] != "001000000000001" {
+		t.Errorf("Expected saved AccountRef1, got %v", refs)
+	}
+	if _, ok := refs["OppRef1"]; ok {
+		t.Error("Expected OppRef1 not to be saved")
+	}
+}
+
+func TestChunkSObjectTreeRecords(t *testing.T) {
+	t.Parallel()
+	children := make([]SObjectTreeRecord, 99)
+	records := make([]SObjectTreeRecord, 3)
+	for i := range records {
+		records[i] = SObjectTreeRecord{Children: map[string][]SObjectTreeRecord{"Contacts": children}}
+	}
+
+	chunks := chunkSObjectTreeRecords(records)
+	if len(chunks) != 2 || len(chunks[0]) != 2 || len(chunks[1]) != 1 {
+		t.Errorf("Unexpected chunks: %d", len(chunks))
+	}
+}
@@ -0,0 +1,369 @@
+package go_salesforce_api_client
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// CoverageReportOptions configures the Cobertura and LCOV exporters
+type CoverageReportOptions struct {
+	SourceDir string    // Directory holding classes/ and triggers/, e.g. force-app/main/default
+	Timestamp time.Time // Time of the Cobertura report, defaults to now
+}
+
+// testOutcome is a passed or failed test method of a RunTestResult
+type testOutcome struct {
+	ClassName  string
+	MethodName string
+	Time       float64 // Milliseconds
+	Failure    *TestFailure
+}
+
+// junitTestSuites is the root element of a JUnit report
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr,omitempty"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+// junitTestSuite holds the test methods of an Apex test class
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+// junitTestCase is a single test method
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+// junitFailure describes why a test method failed
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Content string `xml:",chardata"`
+}
+
+// coberturaCoverage is the root element of a Cobertura report
+type coberturaCoverage struct {
+	XMLName         xml.Name           `xml:"coverage"`
+	LineRate        string             `xml:"line-rate,attr"`
+	BranchRate      string             `xml:"branch-rate,attr"`
+	LinesCovered    int                `xml:"lines-covered,attr"`
+	LinesValid      int                `xml:"lines-valid,attr"`
+	BranchesCovered int                `xml:"branches-covered,attr"`
+	BranchesValid   int                `xml:"branches-valid,attr"`
+	Complexity      string             `xml:"complexity,attr"`
+	Version         string             `xml:"version,attr"`
+	Timestamp       int64              `xml:"timestamp,attr"`
+	Sources         []string           `xml:"sources>source"`
+	Packages        []coberturaPackage `xml:"packages>package"`
+}
+
+// coberturaPackage groups classes or triggers
+type coberturaPackage struct {
+	Name       string           `xml:"name,attr"`
+	LineRate   string           `xml:"line-rate,attr"`
+	BranchRate string           `xml:"branch-rate,attr"`
+	Complexity string           `xml:"complexity,attr"`
+	Classes    []coberturaClass `xml:"classes>class"`
+}
+
+// coberturaClass is the coverage of an Apex class or trigger
+type coberturaClass struct {
+	Name       string          `xml:"name,attr"`
+	Filename   string          `xml:"filename,attr"`
+	LineRate   string          `xml:"line-rate,attr"`
+	BranchRate string          `xml:"branch-rate,attr"`
+	Complexity string          `xml:"complexity,attr"`
+	Methods    struct{}        `xml:"methods"`
+	Lines      []coberturaLine `xml:"lines>line"`
+}
+
+// coberturaLine is a line of an Apex class or trigger
+type coberturaLine struct {
+	Number int `xml:"number,attr"`
+	Hits   int `xml:"hits,attr"`
+}
+
+// outcomes returns the passed and failed test methods sorted by class and method
+func (r *RunTestResult) outcomes() []testOutcome {
+	outcomes := make([]testOutcome, 0, len(r.Successes)+len(r.Failures))
+	for _, s := range r.Successes {
+		outcomes = append(outcomes, testOutcome{
+			ClassName:  qualifiedApexName(s.Namespace, s.Name),
+			MethodName: s.MethodName,
+			Time:       s.Time,
+		})
+	}
+	for i, f := range r.Failures {
+		outcomes = append(outcomes, testOutcome{
+			ClassName:  qualifiedApexName(f.Namespace, f.Name),
+			MethodName: f.MethodName,
+			Time:       f.Time,
+			Failure:    &r.Failures[i],
+		})
+	}
+	sort.SliceStable(outcomes, func(i, j int) bool {
+		if outcomes[i].ClassName != outcomes[j].ClassName {
+			return outcomes[i].ClassName < outcomes[j].ClassName
+		}
+		return outcomes[i].MethodName < outcomes[j].MethodName
+	})
+	return outcomes
+}
+
+// qualifiedApexName prefixes a class name with its namespace
+func qualifiedApexName(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "." + name
+}
+
+// formatSeconds formats milliseconds as seconds
+func formatSeconds(milliseconds float64) string {
+	return fmt.Sprintf("%.3f", milliseconds/1000)
+}
+
+// exceptionType returns the exception type a failure message starts with,
+// e.g. System.AssertException
+func exceptionType(message string) string {
+	name, _, found := strings.Cut(message, ":")
+	if !found || name == "" || strings.ContainsAny(name, " \t\n") {
+		return ""
+	}
+	return name
+}
+
+// WriteJUnit writes the test results as a JUnit XML report with a test suite
+// per Apex class. Times are converted from milliseconds to seconds.
+func (r *RunTestResult) WriteJUnit(w io.Writer, name string) error {
+	report := junitTestSuites{Name: name, Time: formatSeconds(r.TotalTime)}
+
+	var suiteTimes []float64
+	for _, outcome := range r.outcomes() {
+		if len(report.Suites) == 0 || report.Suites[len(report.Suites)-1].Name != outcome.ClassName {
+			report.Suites = append(report.Suites, junitTestSuite{Name: outcome.ClassName})
+			suiteTimes = append(suiteTimes, 0)
+		}
+		suite := &report.Suites[len(report.Suites)-1]
+
+		testCase := junitTestCase{
+			ClassName: outcome.ClassName,
+			Name:      outcome.MethodName,
+			Time:      formatSeconds(outcome.Time),
+		}
+		if outcome.Failure != nil {
+			content := outcome.Failure.Message
+			if outcome.Failure.StackTrace != "" {
+				content += "\n" + outcome.Failure.StackTrace
+			}
+			testCase.Failure = &junitFailure{
+				Message: outcome.Failure.Message,
+				Type:    exceptionType(outcome.Failure.Message),
+				Content: content,
+			}
+			suite.Failures++
+			report.Failures++
+		}
+
+		suite.Cases = append(suite.Cases, testCase)
+		suite.Tests++
+		report.Tests++
+		suiteTimes[len(suiteTimes)-1] += outcome.Time
+		suite.Time = formatSeconds(suiteTimes[len(suiteTimes)-1])
+	}
+
+	return writeXMLReport(w, "", report)
+}
+
+// coverageSourcePath returns the path of an Apex class or trigger in a source tree
+func coverageSourcePath(sourceDir string, coverage CodeCoverageResult) string {
+	if coverage.Type == "Trigger" {
+		return path.Join(sourceDir, "triggers", coverage.Name+".trigger")
+	}
+	return path.Join(sourceDir, "classes", coverage.Name+".cls")
+}
+
+// coverageRate returns the covered share of lines, 1 when there are none
+func coverageRate(covered, valid int) float64 {
+	if valid == 0 {
+		return 1
+	}
+	return float64(covered) / float64(valid)
+}
+
+// uncoveredLines returns the uncovered line numbers in ascending order
+func uncoveredLines(coverage CodeCoverageResult) []int {
+	lines := make([]int, 0, len(coverage.LocationsNotCovered))
+	for _, location := range coverage.LocationsNotCovered {
+		lines = append(lines, location.Line)
+	}
+	sort.Ints(lines)
+	return lines
+}
+
+// coverageTotals returns the covered and total number of lines
+func coverageTotals(coverages []CodeCoverageResult) (covered, valid int) {
+	for _, coverage := range coverages {
+		valid += coverage.NumLocations
+		covered += coverage.NumLocations - coverage.NumLocationsNotCovered
+	}
+	return covered, valid
+}
+
+// CoveragePercent returns the share of lines covered across all classes and
+// triggers as a percentage, 100 when there is no coverage information
+func (r *RunTestResult) CoveragePercent() float64 {
+	return coverageRate(coverageTotals(r.CodeCoverage)) * 100
+}
+
+// WriteCobertura writes the code coverage as a Cobertura XML report. Salesforce
+// only reports which lines are uncovered, so the report lists those lines with
+// zero hits and carries the covered line count in the rates and totals.
+func (r *RunTestResult) WriteCobertura(w io.Writer, options CoverageReportOptions) error {
+	timestamp := options.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	report := coberturaCoverage{
+		BranchRate: "0",
+		Complexity: "0",
+		Version:    "1",
+		Timestamp:  timestamp.UnixMilli(),
+		Sources:    []string{options.SourceDir},
+	}
+	if options.SourceDir == "" {
+		report.Sources = []string{"."}
+	}
+
+	byPackage := map[string][]CodeCoverageResult{}
+	for _, coverage := range r.CodeCoverage {
+		packageName := "classes"
+		if coverage.Type == "Trigger" {
+			packageName = "triggers"
+		}
+		byPackage[packageName] = append(byPackage[packageName], coverage)
+	}
+
+	packageNames := make([]string, 0, len(byPackage))
+	for name := range byPackage {
+		packageNames = append(packageNames, name)
+	}
+	sort.Strings(packageNames)
+
+	for _, name := range packageNames {
+		coverages := byPackage[name]
+		sort.SliceStable(coverages, func(i, j int) bool { return coverages[i].Name < coverages[j].Name })
+
+		pkg := coberturaPackage{
+			Name:       name,
+			LineRate:   fmt.Sprintf("%.4f", coverageRate(coverageTotals(coverages))),
+			BranchRate: "0",
+			Complexity: "0",
+		}
+		for _, coverage := range coverages {
+			class := coberturaClass{
+				Name:       qualifiedApexName(coverage.Namespace, coverage.Name),
+				Filename:   coverageSourcePath("", coverage),
+				LineRate:   fmt.Sprintf("%.4f", coverageRate(coverage.NumLocations-coverage.NumLocationsNotCovered, coverage.NumLocations)),
+				BranchRate: "0",
+				Complexity: "0",
+			}
+			for _, line := range uncoveredLines(coverage) {
+				class.Lines = append(class.Lines, coberturaLine{Number: line})
+			}
+			pkg.Classes = append(pkg.Classes, class)
+		}
+		report.Packages = append(report.Packages, pkg)
+	}
+
+	report.LinesCovered, report.LinesValid = coverageTotals(r.CodeCoverage)
+	report.LineRate = fmt.Sprintf("%.4f", coverageRate(report.LinesCovered, report.LinesValid))
+
+	return writeXMLReport(w, `<!DOCTYPE coverage SYSTEM "http://cobertura.sourceforge.net/xml/coverage-04.dtd">`+"\n", report)
+}
+
+// WriteLCOV writes the code coverage as an LCOV tracefile. Like WriteCobertura,
+// only uncovered lines are listed; LF and LH carry the line totals.
+func (r *RunTestResult) WriteLCOV(w io.Writer, options CoverageReportOptions) error {
+	coverages := append([]CodeCoverageResult(nil), r.CodeCoverage...)
+	sort.SliceStable(coverages, func(i, j int) bool {
+		return coverageSourcePath("", coverages[i]) < coverageSourcePath("", coverages[j])
+	})
+
+	var b strings.Builder
+	for _, coverage := range coverages {
+		b.WriteString("TN:\n")
+		fmt.Fprintf(&b, "SF:%s\n", coverageSourcePath(options.SourceDir, coverage))
+		for _, line := range uncoveredLines(coverage) {
+			fmt.Fprintf(&b, "DA:%d,0\n", line)
+		}
+		fmt.Fprintf(&b, "LF:%d\n", coverage.NumLocations)
+		fmt.Fprintf(&b, "LH:%d\n", coverage.NumLocations-coverage.NumLocationsNotCovered)
+		b.WriteString("end_of_record\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteSummary writes the test outcomes and code coverage as human-readable tables
+func (r *RunTestResult) WriteSummary(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Tests: %d run, %d failed, %ss\n\n", r.NumTestsRun, r.NumFailures, formatSeconds(r.TotalTime))
+	fmt.Fprintln(tw, "OUTCOME\tTEST\tTIME\tMESSAGE")
+	for _, outcome := range r.outcomes() {
+		result, message := "Pass", ""
+		if outcome.Failure != nil {
+			result = "Fail"
+			message, _, _ = strings.Cut(outcome.Failure.Message, "\n")
+		}
+		fmt.Fprintf(tw, "%s\t%s.%s\t%ss\t%s\n", result, outcome.ClassName, outcome.MethodName, formatSeconds(outcome.Time), message)
+	}
+
+	if len(r.CodeCoverage) > 0 {
+		coverages := append([]CodeCoverageResult(nil), r.CodeCoverage...)
+		sort.SliceStable(coverages, func(i, j int) bool { return coverages[i].Name < coverages[j].Name })
+
+		covered, valid := coverageTotals(coverages)
+		fmt.Fprintf(tw, "\nCoverage: %.2f%% (%d/%d lines)\n\n", coverageRate(covered, valid)*100, covered, valid)
+		fmt.Fprintln(tw, "NAME\tTYPE\tLINES\tUNCOVERED\tCOVERAGE")
+		for _, coverage := range coverages {
+			rate := coverageRate(coverage.NumLocations-coverage.NumLocationsNotCovered, coverage.NumLocations)
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%.2f%%\n",
+				qualifiedApexName(coverage.Namespace, coverage.Name), coverage.Type,
+				coverage.NumLocations, coverage.NumLocationsNotCovered, rate*100)
+		}
+	}
+
+	return tw.Flush()
+}
+
+// writeXMLReport writes an indented XML document with an optional doctype
+func writeXMLReport(w io.Writer, doctype string, report any) error {
+	data, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, xml.Header+doctype+string(data)+"\n")
+	return err
+}
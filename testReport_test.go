@@ -0,0 +1,194 @@
+package go_salesforce_api_client_test
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	go_salesforce_api_client "github.com/MASA-JAPAN/go-salesforce-api-client"
+)
+
+func sampleRunTestResult() *go_salesforce_api_client.RunTestResult {
+	return &go_salesforce_api_client.RunTestResult{
+		NumTestsRun: 3,
+		NumFailures: 1,
+		TotalTime:   1250,
+		Successes: []go_salesforce_api_client.TestSuccess{
+			{Name: "AccountServiceTest", MethodName: "testCreate", Time: 500},
+			{Name: "InvoiceTriggerTest", MethodName: "testInsert", Time: 250},
+		},
+		Failures: []go_salesforce_api_client.TestFailure{{
+			Name:       "AccountServiceTest",
+			MethodName: "testUpdate",
+			Message:    "System.AssertException: Assertion Failed: Expected: 1, Actual: 2",
+			StackTrace: "Class.AccountServiceTest.testUpdate: line 12, column 1",
+			Time:       500,
+			Type:       "Class",
+		}},
+		CodeCoverage: []go_salesforce_api_client.CodeCoverageResult{
+			{
+				Name:                   "InvoiceTrigger",
+				Type:                   "Trigger",
+				NumLocations:           10,
+				NumLocationsNotCovered: 0,
+			},
+			{
+				Name:                   "AccountService",
+				Type:                   "Class",
+				NumLocations:           40,
+				NumLocationsNotCovered: 2,
+				LocationsNotCovered:    []go_salesforce_api_client.CodeLocation{{Line: 30}, {Line: 12}},
+			},
+		},
+	}
+}
+
+func TestRunTestResult_WriteJUnit(t *testing.T) {
+	t.Parallel()
+
+	var b strings.Builder
+	if err := sampleRunTestResult().WriteJUnit(&b, "Deploy"); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	var report struct {
+		Name     string `xml:"name,attr"`
+		Tests    int    `xml:"tests,attr"`
+		Failures int    `xml:"failures,attr"`
+		Time     string `xml:"time,attr"`
+		Suites   []struct {
+			Name     string `xml:"name,attr"`
+			Tests    int    `xml:"tests,attr"`
+			Failures int    `xml:"failures,attr"`
+			Time     string `xml:"time,attr"`
+			Cases    []struct {
+				Name    string `xml:"name,attr"`
+				Failure *struct {
+					Message string `xml:"message,attr"`
+					Type    string `xml:"type,attr"`
+					Content string `xml:",chardata"`
+				} `xml:"failure"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+	if err := xml.Unmarshal([]byte(b.String()), &report); err != nil {
+		t.Fatalf("Expected valid XML, got: %v\n%s", err, b.String())
+	}
+
+	if report.Name != "Deploy" || report.Tests != 3 || report.Failures != 1 || report.Time != "1.250" {
+		t.Errorf("Unexpected totals: %+v", report)
+	}
+	if len(report.Suites) != 2 || report.Suites[0].Name != "AccountServiceTest" || report.Suites[0].Time != "1.000" {
+		t.Fatalf("Unexpected suites: %+v", report.Suites)
+	}
+	suite := report.Suites[0]
+	if suite.Tests != 2 || suite.Failures != 1 || suite.Cases[0].Name != "testCreate" || suite.Cases[0].Failure != nil {
+		t.Errorf("Unexpected suite: %+v", suite)
+	}
+	failure := suite.Cases[1].Failure
+	if failure == nil || failure.Type != "System.AssertException" || !strings.Contains(failure.Content, "line 12, column 1") {
+		t.Errorf("Unexpected failure: %+v", failure)
+	}
+}
+
+func TestRunTestResult_WriteCobertura(t *testing.T) {
+	t.Parallel()
+
+	var b strings.Builder
+	err := sampleRunTestResult().WriteCobertura(&b, go_salesforce_api_client.CoverageReportOptions{
+		SourceDir: "force-app/main/default",
+		Timestamp: time.UnixMilli(1700000000000),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	for _, expected := range []string{
+		`<!DOCTYPE coverage SYSTEM "http://cobertura.sourceforge.net/xml/coverage-04.dtd">`,
+		`<coverage line-rate="0.9600" branch-rate="0" lines-covered="48" lines-valid="50"`,
+		`timestamp="1700000000000"`,
+		`<source>force-app/main/default</source>`,
+		`<class name="AccountService" filename="classes/AccountService.cls" line-rate="0.9500"`,
+		`<line number="12" hits="0"></line>`,
+		`<package name="triggers" line-rate="1.0000"`,
+		`filename="triggers/InvoiceTrigger.trigger"`,
+	} {
+		if !strings.Contains(b.String(), expected) {
+			t.Errorf("Expected report to contain %s, got:\n%s", expected, b.String())
+		}
+	}
+	if strings.Index(b.String(), `number="12"`) > strings.Index(b.String(), `number="30"`) {
+		t.Error("Expected uncovered lines in ascending order")
+	}
+}
+
+func TestRunTestResult_WriteLCOV(t *testing.T) {
+	t.Parallel()
+
+	var b strings.Builder
+	err := sampleRunTestResult().WriteLCOV(&b, go_salesforce_api_client.CoverageReportOptions{SourceDir: "src"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	expected := "TN:\nSF:src/classes/AccountService.cls\nDA:12,0\nDA:30,0\nLF:40\nLH:38\nend_of_record\n" +
+		"TN:\nSF:src/triggers/InvoiceTrigger.trigger\nLF:10\nLH:10\nend_of_record\n"
+	if b.String() != expected {
+		t.Errorf("Unexpected tracefile:\n got %q\nwant %q", b.String(), expected)
+	}
+}
+
+func TestRunTestResult_WriteSummary(t *testing.T) {
+	t.Parallel()
+
+	var b strings.Builder
+	if err := sampleRunTestResult().WriteSummary(&b); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	summary := b.String()
+	for _, expected := range []string{
+		"Tests: 3 run, 1 failed, 1.250s",
+		"Fail     AccountServiceTest.testUpdate  0.500s  System.AssertException: Assertion Failed",
+		"Coverage: 96.00% (48/50 lines)",
+		"AccountService  Class    40     2          95.00%",
+	} {
+		if !strings.Contains(summary, expected) {
+			t.Errorf("Expected summary to contain %q, got:\n%s", expected, summary)
+		}
+	}
+}
+
+func TestApexTestRunSummary_Reports(t *testing.T) {
+	t.Parallel()
+
+	summary := &go_salesforce_api_client.ApexTestRunSummary{
+		TestTime: 300,
+		Results: []go_salesforce_api_client.ApexTestMethodResult{
+			{ClassName: "AccountServiceTest", Namespace: "acme", MethodName: "testCreate", Outcome: go_salesforce_api_client.ApexTestOutcomePass, RunTime: 300},
+		},
+		Coverage: []go_salesforce_api_client.ApexCodeCoverage{
+			{ClassOrTriggerID: "01p000000000001", Name: "AccountService", NumLinesCovered: 3, NumLinesUncovered: 1, UncoveredLines: []int{7}},
+		},
+	}
+	result := summary.ToRunTestResult()
+
+	var junit, lcov strings.Builder
+	if err := result.WriteJUnit(&junit, "Tooling"); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !strings.Contains(junit.String(), `<testcase classname="acme.AccountServiceTest" name="testCreate" time="0.300"></testcase>`) {
+		t.Errorf("Unexpected JUnit report:\n%s", junit.String())
+	}
+
+	if err := result.WriteLCOV(&lcov, go_salesforce_api_client.CoverageReportOptions{}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if lcov.String() != "TN:\nSF:classes/AccountService.cls\nDA:7,0\nLF:4\nLH:3\nend_of_record\n" {
+		t.Errorf("Unexpected tracefile: %q", lcov.String())
+	}
+	if result.CoveragePercent() != 75 {
+		t.Errorf("Expected 75%% coverage, got: %v", result.CoveragePercent())
+	}
+}
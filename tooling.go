@@ -12,19 +12,82 @@ import (
 
 // ToolingResponse represents the response structure from Salesforce tooling API
 type ToolingResponse struct {
-	TotalSize int                      `json:"totalSize"`
-	Done      bool                     `json:"done"`
-	Records   []map[string]interface{} `json:"records"`
+	TotalSize      int                      `json:"totalSize"`
+	Done           bool                     `json:"done"`
+	Records        []map[string]interface{} `json:"records"`
+	NextRecordsURL string                   `json:"nextRecordsUrl,omitempty"`
 }
 
 // CustomField represents the structure for creating a Salesforce custom field
 type CustomField struct {
-	FullName string `json:"FullName"`
-	Metadata struct {
-		Label  string `json:"label"`
-		Type   string `json:"type"`
-		Length int    `json:"length,omitempty"`
-	} `json:"Metadata"`
+	FullName string              `json:"FullName"`
+	Metadata CustomFieldMetadata `json:"Metadata"`
+}
+
+// CustomFieldMetadata represents the metadata of a custom field. Only the
+// attributes relevant to the field Type need to be set.
+type CustomFieldMetadata struct {
+	Label          string `json:"label"`
+	Type           string `json:"type"`
+	Length         int    `json:"length,omitempty"`
+	Description    string `json:"description,omitempty"`
+	InlineHelpText string `json:"inlineHelpText,omitempty"`
+	Required       bool   `json:"required,omitempty"`
+	Unique         bool   `json:"unique,omitempty"`
+	ExternalID     bool   `json:"externalId,omitempty"`
+	DefaultValue   string `json:"defaultValue,omitempty"` // A formula expression, e.g. "false" or "'Open'"
+
+	// Number, Currency and Percent fields
+	Precision int `json:"precision,omitempty"`
+	Scale     int `json:"scale,omitempty"`
+
+	// LongTextArea and multi-select picklist fields
+	VisibleLines int `json:"visibleLines,omitempty"`
+
+	// Formula fields, with Type set to the formula return type
+	Formula              string `json:"formula,omitempty"`
+	FormulaTreatBlanksAs string `json:"formulaTreatBlanksAs,omitempty"` // BlankAsZero or BlankAsBlank
+
+	// Lookup and MasterDetail fields
+	ReferenceTo       string `json:"referenceTo,omitempty"`
+	RelationshipName  string `json:"relationshipName,omitempty"`
+	RelationshipLabel string `json:"relationshipLabel,omitempty"`
+	DeleteConstraint  string `json:"deleteConstraint,omitempty"` // SetNull, Restrict or Cascade
+
+	// Picklist and MultiselectPicklist fields
+	ValueSet *CustomFieldValueSet `json:"valueSet,omitempty"`
+}
+
+// CustomFieldValueSet represents the values of a picklist field, either
+// defined locally or referencing a global value set by ValueSetName. It is
+// shared by the Tooling API and MetadataCustomField.
+type CustomFieldValueSet struct {
+	Restricted         bool                           `json:"restricted,omitempty" xml:"restricted,omitempty"`
+	ValueSetDefinition *CustomFieldValueSetDefinition `json:"valueSetDefinition,omitempty" xml:"valueSetDefinition,omitempty"`
+	ValueSetName       string                         `json:"valueSetName,omitempty" xml:"valueSetName,omitempty"`
+}
+
+// CustomFieldValueSetDefinition represents a locally defined list of picklist values
+type CustomFieldValueSetDefinition struct {
+	Sorted bool                       `json:"sorted" xml:"sorted"`
+	Value  []CustomFieldPicklistValue `json:"value" xml:"value"`
+}
+
+// CustomFieldPicklistValue represents a single picklist value
+type CustomFieldPicklistValue struct {
+	FullName string `json:"fullName" xml:"fullName"`
+	Label    string `json:"label,omitempty" xml:"label,omitempty"`
+	Default  bool   `json:"default" xml:"default"`
+}
+
+// NewPicklistValueSet builds a restricted value set from the given values,
+// using each value as both API name and label
+func NewPicklistValueSet(values ...string) *CustomFieldValueSet {
+	definition := &CustomFieldValueSetDefinition{}
+	for _, value := range values {
+		definition.Value = append(definition.Value, CustomFieldPicklistValue{FullName: value, Label: value})
+	}
+	return &CustomFieldValueSet{Restricted: true, ValueSetDefinition: definition}
 }
 
 // QueryToolingAPI executes a SOQL query against the Salesforce Tooling API
@@ -112,3 +175,51 @@ func (c *Client) CreateCustomField(fieldData CustomField) (map[string]interface{
 
 	return response, nil
 }
+
+// queryToolingAll executes a Tooling API query and follows nextRecordsUrl
+// until all records are retrieved, decoding them into v
+func (c *Client) queryToolingAll(soql string, v any) error {
+	resp, err := c.QueryToolingAPI(soql)
+	if err != nil {
+		return err
+	}
+	records := resp.Records
+
+	for !resp.Done && resp.NextRecordsURL != "" {
+		req, err := http.NewRequest(http.MethodGet, c.InstanceURL+resp.NextRecordsURL, nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+c.AccessToken)
+		req.Header.Set("Content-Type", "application/json")
+
+		client := &http.Client{}
+		httpResp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+
+		body, err := io.ReadAll(httpResp.Body)
+		httpResp.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		if httpResp.StatusCode != http.StatusOK {
+			return fmt.Errorf("failed to execute tooling query, status: %d, response: %s", httpResp.StatusCode, string(body))
+		}
+
+		resp = &ToolingResponse{}
+		if err := json.Unmarshal(body, resp); err != nil {
+			return err
+		}
+		records = append(records, resp.Records...)
+	}
+
+	// Round-trip through JSON to decode the records into typed structs
+	jsonData, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(jsonData, v)
+}
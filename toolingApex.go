@@ -0,0 +1,195 @@
+package go_salesforce_api_client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ExecuteAnonymousResult represents the result of executing anonymous Apex
+type ExecuteAnonymousResult struct {
+	Line                int    `json:"line"`
+	Column              int    `json:"column"`
+	Compiled            bool   `json:"compiled"`
+	Success             bool   `json:"success"`
+	CompileProblem      string `json:"compileProblem"`
+	ExceptionMessage    string `json:"exceptionMessage"`
+	ExceptionStackTrace string `json:"exceptionStackTrace"`
+}
+
+// DebugLevel represents a Tooling API DebugLevel record, which sets the log
+// level of each category. Valid levels are NONE, ERROR, WARN, INFO, DEBUG,
+// FINE, FINER and FINEST.
+type DebugLevel struct {
+	ID            string `json:"Id,omitempty"`
+	DeveloperName string `json:"DeveloperName"`
+	MasterLabel   string `json:"MasterLabel"`
+	ApexCode      string `json:"ApexCode"`
+	ApexProfiling string `json:"ApexProfiling"`
+	Callout       string `json:"Callout"`
+	Database      string `json:"Database"`
+	System        string `json:"System"`
+	Validation    string `json:"Validation"`
+	Visualforce   string `json:"Visualforce"`
+	Workflow      string `json:"Workflow"`
+}
+
+// ExecuteAnonymousLogOptions configures the debug log captured by ExecuteAnonymousWithLog
+type ExecuteAnonymousLogOptions struct {
+	DebugLevel *DebugLevel   // Log levels, defaults to DefaultDebugLevel
+	Duration   time.Duration // Lifetime of the temporary trace flag, defaults to 5 minutes
+}
+
+// toolingTimeLayout is the timestamp format accepted by the Tooling API
+const toolingTimeLayout = "2006-01-02T15:04:05.000Z"
+
+// DefaultDebugLevel returns a DebugLevel logging Apex code at FINEST and everything else at INFO
+func DefaultDebugLevel(developerName string) DebugLevel {
+	return DebugLevel{
+		DeveloperName: developerName,
+		MasterLabel:   developerName,
+		ApexCode:      "FINEST",
+		ApexProfiling: "INFO",
+		Callout:       "INFO",
+		Database:      "INFO",
+		System:        "DEBUG",
+		Validation:    "INFO",
+		Visualforce:   "INFO",
+		Workflow:      "INFO",
+	}
+}
+
+// ExecuteAnonymous compiles and runs anonymous Apex using the Tooling API.
+// Compile errors and uncaught exceptions are reported in the result rather
+// than as an error.
+func (c *Client) ExecuteAnonymous(apexCode string) (*ExecuteAnonymousResult, error) {
+	if c.AccessToken == "" || c.InstanceURL == "" {
+		return nil, errors.New("missing authentication details")
+	}
+
+	executeURL := fmt.Sprintf("%s/services/data/v58.0/tooling/executeAnonymous/?anonymousBody=%s", c.InstanceURL, url.QueryEscape(apexCode))
+
+	req, err := http.NewRequest(http.MethodGet, executeURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.AccessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to execute anonymous apex, status: %d, response: %s", resp.StatusCode, string(body))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var result ExecuteAnonymousResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ExecuteAnonymousWithLog runs anonymous Apex with a debug log enabled for the
+// current user and returns the ApexLog body. A temporary DebugLevel and
+// TraceFlag are created and removed afterwards, unless the user already has
+// an active DEVELOPER_LOG trace flag, which is then reused.
+func (c *Client) ExecuteAnonymousWithLog(apexCode string, options ExecuteAnonymousLogOptions) (*ExecuteAnonymousResult, string, error) {
+	userID, err := c.currentUserID()
+	if err != nil {
+		return nil, "", err
+	}
+
+	start := time.Now().UTC()
+	cleanup, err := c.ensureDeveloperTraceFlag(userID, start, options)
+	if err != nil {
+		return nil, "", err
+	}
+	defer cleanup()
+
+	result, err := c.ExecuteAnonymous(apexCode)
+	if err != nil {
+		return nil, "", err
+	}
+
+	logs, err := c.QueryToolingAPI(fmt.Sprintf(
+		"SELECT Id FROM ApexLog WHERE LogUserId = '%s' AND Operation LIKE '%%executeAnonymous%%' AND StartTime >= %s ORDER BY StartTime DESC LIMIT 1",
+		escapeSOQLString(userID), start.Add(-time.Second).Format(time.RFC3339)))
+	if err != nil {
+		return result, "", err
+	}
+	if len(logs.Records) == 0 {
+		return result, "", errors.New("no debug log was generated")
+	}
+
+	logID, _ := logs.Records[0]["Id"].(string)
+	body, err := c.GetApexLogBody(logID)
+	if err != nil {
+		return result, "", err
+	}
+
+	return result, body, nil
+}
+
+// ensureDeveloperTraceFlag makes sure the user has an active DEVELOPER_LOG
+// trace flag and returns a function removing any records it created
+func (c *Client) ensureDeveloperTraceFlag(userID string, start time.Time, options ExecuteAnonymousLogOptions) (func(), error) {
+	existing, err := c.QueryToolingAPI(fmt.Sprintf(
+		"SELECT Id FROM TraceFlag WHERE TracedEntityId = '%s' AND LogType = 'DEVELOPER_LOG' AND ExpirationDate > %s",
+		escapeSOQLString(userID), start.Format(time.RFC3339)))
+	if err != nil {
+		return nil, err
+	}
+	if len(existing.Records) > 0 {
+		return func() {}, nil
+	}
+
+	duration := options.Duration
+	if duration <= 0 {
+		duration = 5 * time.Minute
+	}
+
+	debugLevel := DefaultDebugLevel(fmt.Sprintf("GoSalesforceApiClient_%d", start.UnixNano()))
+	if options.DebugLevel != nil {
+		debugLevel = *options.DebugLevel
+	}
+
+	debugLevelResp, err := c.CreateToolingRecord("DebugLevel", debugLevel)
+	if err != nil {
+		return nil, err
+	}
+	debugLevelID := debugLevelResp.ID
+
+	traceFlagResp, err := c.CreateToolingRecord("TraceFlag", map[string]interface{}{
+		"TracedEntityId": userID,
+		"DebugLevelId":   debugLevelID,
+		"LogType":        "DEVELOPER_LOG",
+		"StartDate":      start.Format(toolingTimeLayout),
+		"ExpirationDate": start.Add(duration).Format(toolingTimeLayout),
+	})
+	if err != nil {
+		_ = c.DeleteToolingRecord("DebugLevel", debugLevelID)
+		return nil, err
+	}
+	traceFlagID := traceFlagResp.ID
+
+	return func() {
+		_ = c.DeleteToolingRecord("TraceFlag", traceFlagID)
+		_ = c.DeleteToolingRecord("DebugLevel", debugLevelID)
+	}, nil
+}
@@ -0,0 +1,508 @@
+package go_salesforce_api_client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Apex test outcomes reported by ApexTestResult
+const (
+	ApexTestOutcomePass        = "Pass"
+	ApexTestOutcomeFail        = "Fail"
+	ApexTestOutcomeCompileFail = "CompileFail"
+	ApexTestOutcomeSkip        = "Skip"
+)
+
+// ApexTestSelection selects test methods of a single class. All test methods
+// of the class run when TestMethods is empty.
+type ApexTestSelection struct {
+	ClassName   string   `json:"className,omitempty"`
+	ClassID     string   `json:"classId,omitempty"`
+	TestMethods []string `json:"testMethods,omitempty"`
+}
+
+// RunTestsOptions selects the Apex tests to run. Use only one of ClassNames,
+// ClassIDs, SuiteNames or Tests, or a TestLevel of RunLocalTests or RunAllTestsInOrg.
+type RunTestsOptions struct {
+	ClassNames       []string
+	ClassIDs         []string
+	SuiteNames       []string
+	Tests            []ApexTestSelection
+	TestLevel        string // RunSpecifiedTests, RunLocalTests or RunAllTestsInOrg
+	SkipCodeCoverage bool
+}
+
+// ApexTestQueueItem represents a test class queued by an asynchronous test run
+type ApexTestQueueItem struct {
+	ID             string `json:"Id"`
+	ApexClassID    string `json:"ApexClassId"`
+	ClassName      string `json:"-"`
+	Status         string `json:"Status"` // Holding, Queued, Preparing, Processing, Completed, Failed or Aborted
+	ExtendedStatus string `json:"ExtendedStatus"`
+}
+
+// ApexTestMethodResult represents the outcome of a single test method
+type ApexTestMethodResult struct {
+	ID          string `json:"Id"`
+	ApexClassID string `json:"ApexClassId"`
+	ClassName   string `json:"-"`
+	Namespace   string `json:"-"`
+	MethodName  string `json:"MethodName"`
+	Outcome     string `json:"Outcome"`
+	Message     string `json:"Message"`
+	StackTrace  string `json:"StackTrace"`
+	RunTime     int    `json:"RunTime"` // Milliseconds
+}
+
+// ApexCodeCoverage represents the aggregate code coverage of a class or trigger
+type ApexCodeCoverage struct {
+	ClassOrTriggerID  string `json:"ApexClassOrTriggerId"`
+	Name              string `json:"-"`
+	NumLinesCovered   int    `json:"NumLinesCovered"`
+	NumLinesUncovered int    `json:"NumLinesUncovered"`
+	CoveredLines      []int  `json:"-"`
+	UncoveredLines    []int  `json:"-"`
+}
+
+// ApexTestRunSummary summarizes an asynchronous Apex test run
+type ApexTestRunSummary struct {
+	AsyncApexJobID   string
+	Status           string // Queued, Processing, Completed, Aborted or Failed
+	ClassesEnqueued  int
+	ClassesCompleted int
+	MethodsEnqueued  int
+	MethodsCompleted int
+	MethodsFailed    int
+	TestTime         int // Milliseconds
+	QueueItems       []ApexTestQueueItem
+	Results          []ApexTestMethodResult
+	Coverage         []ApexCodeCoverage // Coverage produced by the test classes of the run
+}
+
+// ApexTestWaitOptions configures WaitForApexTestRun
+type ApexTestWaitOptions struct {
+	WaitOptions
+	SkipCodeCoverage bool                              // Don't query the coverage of the run once it is done
+	OnProgress       func(summary *ApexTestRunSummary) // Called after every poll
+}
+
+// requestBody builds the runTests request body
+func (o RunTestsOptions) requestBody() map[string]interface{} {
+	body := map[string]interface{}{}
+	if len(o.ClassNames) > 0 {
+		body["classNames"] = strings.Join(o.ClassNames, ",")
+	}
+	if len(o.ClassIDs) > 0 {
+		body["classids"] = strings.Join(o.ClassIDs, ",")
+	}
+	if len(o.SuiteNames) > 0 {
+		body["suiteNames"] = strings.Join(o.SuiteNames, ",")
+	}
+	if len(o.Tests) > 0 {
+		body["tests"] = o.Tests
+	}
+	if o.TestLevel != "" {
+		body["testLevel"] = o.TestLevel
+	}
+	if o.SkipCodeCoverage {
+		body["skipCodeCoverage"] = true
+	}
+	return body
+}
+
+// postRunTests sends a runTests request to the Tooling API and returns the response body
+func (c *Client) postRunTests(resource string, options RunTestsOptions) ([]byte, error) {
+	if c.AccessToken == "" || c.InstanceURL == "" {
+		return nil, errors.New("missing authentication details")
+	}
+
+	url := fmt.Sprintf("%s/services/data/v58.0/tooling/%s", c.InstanceURL, resource)
+
+	jsonData, err := json.Marshal(options.requestBody())
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.AccessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to run tests, status: %d, response: %s", resp.StatusCode, string(body))
+	}
+
+	return body, nil
+}
+
+// RunTestsAsynchronous enqueues Apex tests and returns the AsyncApexJob ID of the run
+func (c *Client) RunTestsAsynchronous(options RunTestsOptions) (string, error) {
+	body, err := c.postRunTests("runTestsAsynchronous", options)
+	if err != nil {
+		return "", err
+	}
+
+	var jobID string
+	if err := json.Unmarshal(body, &jobID); err != nil {
+		return "", fmt.Errorf("failed to parse test run ID: %w", err)
+	}
+
+	return jobID, nil
+}
+
+// RunTestsSynchronous runs Apex tests and waits for the results. Salesforce
+// only allows tests from a single class in a synchronous run.
+func (c *Client) RunTestsSynchronous(options RunTestsOptions) (*RunTestResult, error) {
+	body, err := c.postRunTests("runTestsSynchronous", options)
+	if err != nil {
+		return nil, err
+	}
+
+	var result RunTestResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetApexTestRunSummary retrieves the current state of an asynchronous test
+// run, its queue items and the test method results reported so far
+func (c *Client) GetApexTestRunSummary(asyncApexJobID string) (*ApexTestRunSummary, error) {
+	summary := &ApexTestRunSummary{AsyncApexJobID: asyncApexJobID}
+
+	var runResults []struct {
+		Status           string `json:"Status"`
+		ClassesEnqueued  int    `json:"ClassesEnqueued"`
+		ClassesCompleted int    `json:"ClassesCompleted"`
+		MethodsEnqueued  int    `json:"MethodsEnqueued"`
+		MethodsCompleted int    `json:"MethodsCompleted"`
+		MethodsFailed    int    `json:"MethodsFailed"`
+		TestTime         int    `json:"TestTime"`
+	}
+	if err := c.queryToolingAll(fmt.Sprintf(
+		"SELECT Status, ClassesEnqueued, ClassesCompleted, MethodsEnqueued, MethodsCompleted, MethodsFailed, TestTime FROM ApexTestRunResult WHERE AsyncApexJobId = '%s'",
+		escapeSOQLString(asyncApexJobID)), &runResults); err != nil {
+		return nil, err
+	}
+	if len(runResults) > 0 {
+		run := runResults[0]
+		summary.Status = run.Status
+		summary.ClassesEnqueued = run.ClassesEnqueued
+		summary.ClassesCompleted = run.ClassesCompleted
+		summary.MethodsEnqueued = run.MethodsEnqueued
+		summary.MethodsCompleted = run.MethodsCompleted
+		summary.MethodsFailed = run.MethodsFailed
+		summary.TestTime = run.TestTime
+	}
+
+	var queueItems []struct {
+		ApexTestQueueItem
+		ApexClass *toolingClassRef `json:"ApexClass"`
+	}
+	if err := c.queryToolingAll(fmt.Sprintf(
+		"SELECT Id, ApexClassId, ApexClass.Name, Status, ExtendedStatus FROM ApexTestQueueItem WHERE ParentJobId = '%s'",
+		escapeSOQLString(asyncApexJobID)), &queueItems); err != nil {
+		return nil, err
+	}
+	for _, item := range queueItems {
+		item.ApexTestQueueItem.ClassName = item.ApexClass.name()
+		summary.QueueItems = append(summary.QueueItems, item.ApexTestQueueItem)
+	}
+
+	var results []struct {
+		ApexTestMethodResult
+		ApexClass *toolingClassRef `json:"ApexClass"`
+	}
+	if err := c.queryToolingAll(fmt.Sprintf(
+		"SELECT Id, ApexClassId, ApexClass.Name, ApexClass.NamespacePrefix, MethodName, Outcome, Message, StackTrace, RunTime FROM ApexTestResult WHERE AsyncApexJobId = '%s'",
+		escapeSOQLString(asyncApexJobID)), &results); err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		result.ApexTestMethodResult.ClassName = result.ApexClass.name()
+		if result.ApexClass != nil {
+			result.ApexTestMethodResult.Namespace = result.ApexClass.NamespacePrefix
+		}
+		summary.Results = append(summary.Results, result.ApexTestMethodResult)
+	}
+
+	return summary, nil
+}
+
+// toolingClassRef represents the ApexClass relationship of Tooling API records
+type toolingClassRef struct {
+	Name            string `json:"Name"`
+	NamespacePrefix string `json:"NamespacePrefix"`
+}
+
+// name returns the class name, or an empty string for a missing relationship
+func (r *toolingClassRef) name() string {
+	if r == nil {
+		return ""
+	}
+	return r.Name
+}
+
+// GetApexCodeCoverage retrieves the org-wide aggregate code coverage of every
+// class and trigger with coverage data
+func (c *Client) GetApexCodeCoverage() ([]ApexCodeCoverage, error) {
+	var records []struct {
+		ApexCodeCoverage
+		ApexClassOrTrigger *toolingClassRef `json:"ApexClassOrTrigger"`
+		Coverage           *struct {
+			CoveredLines   []int `json:"coveredLines"`
+			UncoveredLines []int `json:"uncoveredLines"`
+		} `json:"Coverage"`
+	}
+	if err := c.queryToolingAll(
+		"SELECT ApexClassOrTriggerId, ApexClassOrTrigger.Name, NumLinesCovered, NumLinesUncovered, Coverage FROM ApexCodeCoverageAggregate",
+		&records); err != nil {
+		return nil, err
+	}
+
+	coverage := make([]ApexCodeCoverage, 0, len(records))
+	for _, record := range records {
+		record.ApexCodeCoverage.Name = record.ApexClassOrTrigger.name()
+		if record.Coverage != nil {
+			record.ApexCodeCoverage.CoveredLines = record.Coverage.CoveredLines
+			record.ApexCodeCoverage.UncoveredLines = record.Coverage.UncoveredLines
+		}
+		coverage = append(coverage, record.ApexCodeCoverage)
+	}
+
+	sort.Slice(coverage, func(i, j int) bool { return coverage[i].Name < coverage[j].Name })
+	return coverage, nil
+}
+
+// GetApexCodeCoverageForTestClasses retrieves the code coverage produced by the
+// given test classes in their latest run, merging the lines covered by each
+// of their test methods
+func (c *Client) GetApexCodeCoverageForTestClasses(testClassIDs ...string) ([]ApexCodeCoverage, error) {
+	if len(testClassIDs) == 0 {
+		return nil, nil
+	}
+
+	quoted := make([]string, 0, len(testClassIDs))
+	for _, id := range testClassIDs {
+		quoted = append(quoted, "'"+escapeSOQLString(id)+"'")
+	}
+
+	var records []struct {
+		ApexClassOrTriggerID string           `json:"ApexClassOrTriggerId"`
+		ApexClassOrTrigger   *toolingClassRef `json:"ApexClassOrTrigger"`
+		NumLinesCovered      int              `json:"NumLinesCovered"`
+		NumLinesUncovered    int              `json:"NumLinesUncovered"`
+		Coverage             *struct {
+			CoveredLines   []int `json:"coveredLines"`
+			UncoveredLines []int `json:"uncoveredLines"`
+		} `json:"Coverage"`
+	}
+	soql := "SELECT ApexClassOrTriggerId, ApexClassOrTrigger.Name, NumLinesCovered, NumLinesUncovered, Coverage FROM ApexCodeCoverage" +
+		" WHERE ApexTestClassId IN (" + strings.Join(quoted, ", ") + ")"
+	if err := c.queryToolingAll(soql, &records); err != nil {
+		return nil, err
+	}
+
+	// A line is covered when any test method covered it
+	type lineSets struct {
+		name      string
+		covered   map[int]bool
+		uncovered map[int]bool
+	}
+	byID := map[string]*lineSets{}
+	var ids []string
+	for _, record := range records {
+		lines, ok := byID[record.ApexClassOrTriggerID]
+		if !ok {
+			lines = &lineSets{name: record.ApexClassOrTrigger.name(), covered: map[int]bool{}, uncovered: map[int]bool{}}
+			byID[record.ApexClassOrTriggerID] = lines
+			ids = append(ids, record.ApexClassOrTriggerID)
+		}
+		if record.Coverage == nil {
+			continue
+		}
+		for _, line := range record.Coverage.CoveredLines {
+			lines.covered[line] = true
+		}
+		for _, line := range record.Coverage.UncoveredLines {
+			lines.uncovered[line] = true
+		}
+	}
+
+	coverage := make([]ApexCodeCoverage, 0, len(ids))
+	for _, id := range ids {
+		lines := byID[id]
+		result := ApexCodeCoverage{ClassOrTriggerID: id, Name: lines.name}
+		for line := range lines.covered {
+			result.CoveredLines = append(result.CoveredLines, line)
+		}
+		for line := range lines.uncovered {
+			if !lines.covered[line] {
+				result.UncoveredLines = append(result.UncoveredLines, line)
+			}
+		}
+		sort.Ints(result.CoveredLines)
+		sort.Ints(result.UncoveredLines)
+		result.NumLinesCovered = len(result.CoveredLines)
+		result.NumLinesUncovered = len(result.UncoveredLines)
+		coverage = append(coverage, result)
+	}
+
+	sort.Slice(coverage, func(i, j int) bool { return coverage[i].Name < coverage[j].Name })
+	return coverage, nil
+}
+
+// testClassIDs returns the distinct test classes of the run
+func (s *ApexTestRunSummary) testClassIDs() []string {
+	var ids []string
+	seen := map[string]bool{}
+	for _, item := range s.QueueItems {
+		if item.ApexClassID != "" && !seen[item.ApexClassID] {
+			seen[item.ApexClassID] = true
+			ids = append(ids, item.ApexClassID)
+		}
+	}
+	for _, result := range s.Results {
+		if result.ApexClassID != "" && !seen[result.ApexClassID] {
+			seen[result.ApexClassID] = true
+			ids = append(ids, result.ApexClassID)
+		}
+	}
+	return ids
+}
+
+// isDone reports whether every queued test class has finished
+func (s *ApexTestRunSummary) isDone() bool {
+	switch s.Status {
+	case "Completed", "Failed", "Aborted":
+		return true
+	}
+	if len(s.QueueItems) == 0 {
+		return false
+	}
+	for _, item := range s.QueueItems {
+		if item.Status != "Completed" && item.Status != "Failed" && item.Status != "Aborted" {
+			return false
+		}
+	}
+	return true
+}
+
+// WaitForApexTestRun polls an asynchronous test run until all of its test
+// classes have finished and returns the final summary including the code
+// coverage produced by the run's test classes
+func (c *Client) WaitForApexTestRun(ctx context.Context, asyncApexJobID string, options ApexTestWaitOptions) (*ApexTestRunSummary, error) {
+	var summary *ApexTestRunSummary
+	err := poll(ctx, options.WaitOptions, func() (bool, error) {
+		var err error
+		summary, err = c.GetApexTestRunSummary(asyncApexJobID)
+		if err != nil {
+			return false, err
+		}
+		if options.OnProgress != nil {
+			options.OnProgress(summary)
+		}
+		return summary.isDone(), nil
+	})
+	if err != nil {
+		return summary, err
+	}
+
+	if !options.SkipCodeCoverage {
+		summary.Coverage, err = c.GetApexCodeCoverageForTestClasses(summary.testClassIDs()...)
+		if err != nil {
+			return summary, err
+		}
+	}
+
+	return summary, nil
+}
+
+// Failures returns the test methods that did not pass
+func (s *ApexTestRunSummary) Failures() []ApexTestMethodResult {
+	var failures []ApexTestMethodResult
+	for _, result := range s.Results {
+		if result.Outcome == ApexTestOutcomeFail || result.Outcome == ApexTestOutcomeCompileFail {
+			failures = append(failures, result)
+		}
+	}
+	return failures
+}
+
+// ToRunTestResult converts the summary into the RunTestResult format used by
+// deploy results, so both can be processed the same way
+func (s *ApexTestRunSummary) ToRunTestResult() *RunTestResult {
+	result := &RunTestResult{
+		TotalTime: float64(s.TestTime),
+	}
+
+	for _, r := range s.Results {
+		switch r.Outcome {
+		case ApexTestOutcomePass:
+			result.Successes = append(result.Successes, TestSuccess{
+				ID:         r.ApexClassID,
+				MethodName: r.MethodName,
+				Name:       r.ClassName,
+				Namespace:  r.Namespace,
+				Time:       float64(r.RunTime),
+			})
+		case ApexTestOutcomeFail, ApexTestOutcomeCompileFail:
+			result.Failures = append(result.Failures, TestFailure{
+				ID:         r.ApexClassID,
+				Message:    r.Message,
+				MethodName: r.MethodName,
+				Name:       r.ClassName,
+				Namespace:  r.Namespace,
+				StackTrace: r.StackTrace,
+				Time:       float64(r.RunTime),
+				Type:       "Class",
+			})
+		default:
+			continue
+		}
+		result.NumTestsRun++
+	}
+	result.NumFailures = len(result.Failures)
+
+	for _, cov := range s.Coverage {
+		coverage := CodeCoverageResult{
+			ID:                     cov.ClassOrTriggerID,
+			Name:                   cov.Name,
+			NumLocations:           cov.NumLinesCovered + cov.NumLinesUncovered,
+			NumLocationsNotCovered: cov.NumLinesUncovered,
+			Type:                   "Class",
+		}
+		// Trigger IDs start with the 01q key prefix
+		if strings.HasPrefix(cov.ClassOrTriggerID, "01q") {
+			coverage.Type = "Trigger"
+		}
+		for _, line := range cov.UncoveredLines {
+			coverage.LocationsNotCovered = append(coverage.LocationsNotCovered, CodeLocation{Line: line})
+		}
+		result.CodeCoverage = append(result.CodeCoverage, coverage)
+	}
+
+	return result
+}
@@ -0,0 +1,147 @@
+package go_salesforce_api_client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRunTestsAsynchronous(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/data/v58.0/tooling/runTestsAsynchronous" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode request body: %v", err)
+		}
+		if body["suiteNames"] != "SmokeTests,Regression" || body["testLevel"] != "RunSpecifiedTests" {
+			t.Errorf("Unexpected request body %v", body)
+		}
+		_, _ = io.WriteString(w, `"7071x000000001"`)
+	}))
+	defer server.Close()
+
+	client := &Client{AccessToken: "mock_token", InstanceURL: server.URL}
+	jobID, err := client.RunTestsAsynchronous(RunTestsOptions{
+		SuiteNames: []string{"SmokeTests", "Regression"},
+		TestLevel:  "RunSpecifiedTests",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if jobID != "7071x000000001" {
+		t.Errorf("Unexpected job ID %s", jobID)
+	}
+}
+
+func TestRunTestsSynchronous(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Tests []ApexTestSelection `json:"tests"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode request body: %v", err)
+		}
+		if len(body.Tests) != 1 || body.Tests[0].ClassName != "AccountTest" || len(body.Tests[0].TestMethods) != 1 {
+			t.Errorf("Unexpected tests %+v", body.Tests)
+		}
+		_, _ = io.WriteString(w, `{"numFailures":1,"numTestsRun":1,"totalTime":42,"successes":[],
+			"failures":[{"id":"01p1","methodName":"testInsert","name":"AccountTest","message":"Assertion Failed","stackTrace":"Class.AccountTest.testInsert: line 5, column 1","time":40,"type":"Class"}],
+			"codeCoverage":[{"id":"01p2","name":"AccountService","numLocations":10,"numLocationsNotCovered":2,"locationsNotCovered":[{"line":7},{"line":8}],"type":"Class"}]}`)
+	}))
+	defer server.Close()
+
+	client := &Client{AccessToken: "mock_token", InstanceURL: server.URL}
+	result, err := client.RunTestsSynchronous(RunTestsOptions{
+		Tests: []ApexTestSelection{{ClassName: "AccountTest", TestMethods: []string{"testInsert"}}},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.NumFailures != 1 || result.Failures[0].StackTrace == "" {
+		t.Errorf("Unexpected failures %+v", result.Failures)
+	}
+	if len(result.CodeCoverage) != 1 || len(result.CodeCoverage[0].LocationsNotCovered) != 2 {
+		t.Errorf("Unexpected coverage %+v", result.CodeCoverage)
+	}
+}
+
+func TestWaitForApexTestRun(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		q := r.URL.Query().Get("q")
+		switch {
+		case strings.Contains(q, "FROM ApexTestRunResult"):
+			polls++
+			status := "Processing"
+			if polls > 1 {
+				status = "Completed"
+			}
+			_, _ = io.WriteString(w, `{"totalSize":1,"done":true,"records":[{"Status":"`+status+`","ClassesEnqueued":1,"ClassesCompleted":1,"MethodsEnqueued":2,"MethodsCompleted":2,"MethodsFailed":1,"TestTime":120}]}`)
+		case strings.Contains(q, "FROM ApexTestQueueItem"):
+			_, _ = io.WriteString(w, `{"totalSize":1,"done":true,"records":[{"Id":"7091","ApexClassId":"01p1","ApexClass":{"Name":"AccountTest"},"Status":"Processing"}]}`)
+		case strings.Contains(q, "FROM ApexTestResult"):
+			_, _ = io.WriteString(w, `{"totalSize":1,"done":false,"nextRecordsUrl":"/services/data/v58.0/tooling/query/01g-2000","records":[{"Id":"07M1","ApexClassId":"01p1","ApexClass":{"Name":"AccountTest","NamespacePrefix":null},"MethodName":"testInsert","Outcome":"Pass","RunTime":50}]}`)
+		case r.URL.Path == "/services/data/v58.0/tooling/query/01g-2000":
+			_, _ = io.WriteString(w, `{"totalSize":1,"done":true,"records":[{"Id":"07M2","ApexClassId":"01p1","ApexClass":{"Name":"AccountTest"},"MethodName":"testUpdate","Outcome":"Fail","Message":"System.AssertException","StackTrace":"Class.AccountTest.testUpdate: line 9, column 1","RunTime":70}]}`)
+		case strings.Contains(q, "FROM ApexCodeCoverage WHERE ApexTestClassId IN ('01p1')"):
+			// One row per test method; lines covered by either method count as covered
+			_, _ = io.WriteString(w, `{"totalSize":3,"done":true,"records":[
+				{"ApexClassOrTriggerId":"01q1","ApexClassOrTrigger":{"Name":"AccountTrigger"},"NumLinesCovered":4,"NumLinesUncovered":0,"Coverage":{"coveredLines":[1,2,3,4],"uncoveredLines":[]}},
+				{"ApexClassOrTriggerId":"01p2","ApexClassOrTrigger":{"Name":"AccountService"},"NumLinesCovered":2,"NumLinesUncovered":2,"Coverage":{"coveredLines":[1,2],"uncoveredLines":[3,6]}},
+				{"ApexClassOrTriggerId":"01p2","ApexClassOrTrigger":{"Name":"AccountService"},"NumLinesCovered":2,"NumLinesUncovered":2,"Coverage":{"coveredLines":[1,3],"uncoveredLines":[2,6]}}]}`)
+		default:
+			t.Errorf("Unexpected request %s %s", r.URL.Path, q)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{AccessToken: "mock_token", InstanceURL: server.URL}
+	progress := 0
+	summary, err := client.WaitForApexTestRun(context.Background(), "7071x000000001", ApexTestWaitOptions{
+		WaitOptions: WaitOptions{PollInterval: time.Millisecond},
+		OnProgress:  func(*ApexTestRunSummary) { progress++ },
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if progress != 2 || summary.Status != "Completed" {
+		t.Errorf("Expected 2 polls ending in Completed, got %d polls and %s", progress, summary.Status)
+	}
+	if len(summary.Results) != 2 || summary.Results[0].ClassName != "AccountTest" {
+		t.Errorf("Unexpected results %+v", summary.Results)
+	}
+	failures := summary.Failures()
+	if len(failures) != 1 || failures[0].MethodName != "testUpdate" || failures[0].StackTrace == "" {
+		t.Errorf("Unexpected failures %+v", failures)
+	}
+	if len(summary.Coverage) != 2 || summary.Coverage[0].Name != "AccountService" {
+		t.Fatalf("Expected coverage sorted by name, got %+v", summary.Coverage)
+	}
+	if service := summary.Coverage[0]; service.NumLinesCovered != 3 || service.NumLinesUncovered != 1 ||
+		len(service.UncoveredLines) != 1 || service.UncoveredLines[0] != 6 {
+		t.Errorf("Expected coverage merged across test methods, got %+v", service)
+	}
+
+	result := summary.ToRunTestResult()
+	if result.NumTestsRun != 2 || result.NumFailures != 1 || len(result.Successes) != 1 {
+		t.Errorf("Unexpected run test result %+v", result)
+	}
+	if result.CodeCoverage[1].Type != "Trigger" || result.CodeCoverage[0].NumLocations != 4 ||
+		len(result.CodeCoverage[0].LocationsNotCovered) != 1 || result.CodeCoverage[0].LocationsNotCovered[0].Line != 6 {
+		t.Errorf("Unexpected code coverage %+v", result.CodeCoverage)
+	}
+}
@@ -0,0 +1,106 @@
+package go_salesforce_api_client
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestExecuteAnonymous(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/data/v58.0/tooling/executeAnonymous/" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("anonymousBody"); got != "System.debug('a & b');" {
+			t.Errorf("Unexpected anonymousBody %q", got)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{"line":1,"column":1,"compiled":true,"success":false,"compileProblem":null,"exceptionStackTrace":"AnonymousBlock: line 1, column 1","exceptionMessage":"System.NullPointerException: Attempt to de-reference a null object"}`)
+	}))
+	defer server.Close()
+
+	client := &Client{AccessToken: "mock_token", InstanceURL: server.URL}
+	result, err := client.ExecuteAnonymous("System.debug('a & b');")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !result.Compiled || result.Success {
+		t.Errorf("Expected compiled but failed result, got %+v", result)
+	}
+	if !strings.HasPrefix(result.ExceptionMessage, "System.NullPointerException") {
+		t.Errorf("Unexpected exception message: %s", result.ExceptionMessage)
+	}
+}
+
+func TestExecuteAnonymousWithLog(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.URL.Path == "/services/oauth2/userinfo":
+			_, _ = io.WriteString(w, `{"user_id":"005ME"}`)
+		case r.URL.Path == "/services/data/v58.0/tooling/query/":
+			q := r.URL.Query().Get("q")
+			if strings.Contains(q, "FROM TraceFlag") {
+				_, _ = io.WriteString(w, `{"totalSize":0,"done":true,"records":[]}`)
+			} else {
+				_, _ = io.WriteString(w, `{"totalSize":1,"done":true,"records":[{"Id":"07L000000000001"}]}`)
+			}
+		case r.Method == http.MethodPost:
+			var record map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&record)
+			calls = append(calls, "create "+r.URL.Path)
+			if strings.Contains(r.URL.Path, "TraceFlag") && record["DebugLevelId"] != "7dl000000000001" {
+				t.Errorf("Expected trace flag to use the debug level, got %v", record)
+			}
+			w.WriteHeader(http.StatusCreated)
+			if strings.Contains(r.URL.Path, "DebugLevel") {
+				_, _ = io.WriteString(w, `{"id":"7dl000000000001","success":true,"errors":[]}`)
+			} else {
+				_, _ = io.WriteString(w, `{"id":"7tf000000000001","success":true,"errors":[]}`)
+			}
+		case r.Method == http.MethodDelete:
+			calls = append(calls, "delete "+r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		case strings.HasSuffix(r.URL.Path, "/executeAnonymous/"):
+			_, _ = io.WriteString(w, `{"line":-1,"column":-1,"compiled":true,"success":true}`)
+		case r.URL.Path == "/services/data/v58.0/tooling/sobjects/ApexLog/07L000000000001/Body":
+			_, _ = io.WriteString(w, "58.0 APEX_CODE,FINEST\n12:00:00.0 (1)|USER_DEBUG|[1]|DEBUG|hello\n")
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{AccessToken: "mock_token", InstanceURL: server.URL}
+	result, log, err := client.ExecuteAnonymousWithLog("System.debug('hello');", ExecuteAnonymousLogOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !result.Success {
+		t.Errorf("Expected success, got %+v", result)
+	}
+	if !strings.Contains(log, "USER_DEBUG|[1]|DEBUG|hello") {
+		t.Errorf("Unexpected log body: %s", log)
+	}
+
+	sort.Strings(calls)
+	expected := []string{
+		"create /services/data/v58.0/tooling/sobjects/DebugLevel/",
+		"create /services/data/v58.0/tooling/sobjects/TraceFlag/",
+		"delete /services/data/v58.0/tooling/sobjects/DebugLevel/7dl000000000001",
+		"delete /services/data/v58.0/tooling/sobjects/TraceFlag/7tf000000000001",
+	}
+	if strings.Join(calls, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Unexpected calls: %v", calls)
+	}
+}
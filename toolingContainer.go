@@ -0,0 +1,171 @@
+package go_salesforce_api_client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ContainerAsyncRequest states
+const (
+	ContainerAsyncStateQueued      = "Queued"
+	ContainerAsyncStateCompleted   = "Completed"
+	ContainerAsyncStateFailed      = "Failed"
+	ContainerAsyncStateError       = "Error"
+	ContainerAsyncStateAborted     = "Aborted"
+	ContainerAsyncStateInvalidated = "Invalidated"
+)
+
+// ErrApexSaveFailed is returned when a ContainerAsyncRequest doesn't complete
+var ErrApexSaveFailed = errors.New("apex save failed")
+
+// ApexSource represents the new source of an existing Apex class or trigger
+type ApexSource struct {
+	Type            string // ApexClass or ApexTrigger
+	Name            string
+	Body            string
+	ContentEntityID string // ID of the class or trigger, looked up by Name when empty
+}
+
+// SaveApexOptions configures SaveApex
+type SaveApexOptions struct {
+	WaitOptions
+	CheckOnly     bool   // Compile without saving
+	ContainerName string // Defaults to a generated unique name
+	KeepContainer bool   // Don't delete the MetadataContainer afterwards
+}
+
+// SaveApexResult represents the outcome of a ContainerAsyncRequest
+type SaveApexResult struct {
+	ContainerID             string
+	ContainerAsyncRequestID string
+	State                   string
+	ErrorMessage            string
+	DeployDetails           *DeployDetails
+}
+
+// containerAsyncRequest is the Tooling API representation of a ContainerAsyncRequest
+type containerAsyncRequest struct {
+	State         string         `json:"State"`
+	ErrorMsg      string         `json:"ErrorMsg"`
+	DeployDetails *DeployDetails `json:"DeployDetails"`
+}
+
+// Success reports whether the sources compiled and, unless check-only, were saved
+func (r *SaveApexResult) Success() bool {
+	return r.State == ContainerAsyncStateCompleted
+}
+
+// CompileErrors returns the compile errors with their line and column numbers
+func (r *SaveApexResult) CompileErrors() []ComponentFailure {
+	if r.DeployDetails == nil {
+		return nil
+	}
+	return r.DeployDetails.ComponentFailures
+}
+
+// SaveApex compiles and saves Apex classes and triggers through a Tooling API
+// MetadataContainer. Compile errors are returned in the result together with
+// ErrApexSaveFailed. The container is deleted afterwards unless KeepContainer is set.
+func (c *Client) SaveApex(ctx context.Context, sources []ApexSource, options SaveApexOptions) (*SaveApexResult, error) {
+	if len(sources) == 0 {
+		return nil, errors.New("no apex sources to save")
+	}
+	sources = append([]ApexSource(nil), sources...)
+	for i := range sources {
+		if sources[i].Type != "ApexClass" && sources[i].Type != "ApexTrigger" {
+			return nil, fmt.Errorf("unsupported apex source type %q", sources[i].Type)
+		}
+		if sources[i].ContentEntityID == "" {
+			id, err := c.lookupApexEntityID(sources[i].Type, sources[i].Name)
+			if err != nil {
+				return nil, err
+			}
+			sources[i].ContentEntityID = id
+		}
+	}
+
+	containerName := options.ContainerName
+	if containerName == "" {
+		// Container names are limited to 32 characters
+		containerName = "GoSalesforce" + strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+
+	container, err := c.CreateToolingRecord("MetadataContainer", map[string]interface{}{"Name": containerName})
+	if err != nil {
+		return nil, err
+	}
+	result := &SaveApexResult{ContainerID: container.ID}
+	if !options.KeepContainer {
+		defer func() { _ = c.DeleteToolingRecord("MetadataContainer", container.ID) }()
+	}
+
+	for _, source := range sources {
+		if _, err := c.CreateToolingRecord(source.Type+"Member", map[string]interface{}{
+			"MetadataContainerId": container.ID,
+			"ContentEntityId":     source.ContentEntityID,
+			"Body":                source.Body,
+		}); err != nil {
+			return result, err
+		}
+	}
+
+	request, err := c.CreateToolingRecord("ContainerAsyncRequest", map[string]interface{}{
+		"MetadataContainerId": container.ID,
+		"IsCheckOnly":         options.CheckOnly,
+	})
+	if err != nil {
+		return result, err
+	}
+	result.ContainerAsyncRequestID = request.ID
+
+	err = poll(ctx, options.WaitOptions, func() (bool, error) {
+		var status containerAsyncRequest
+		if err := c.GetToolingRecordInto("ContainerAsyncRequest", request.ID, &status); err != nil {
+			return false, err
+		}
+		result.State = status.State
+		result.ErrorMessage = status.ErrorMsg
+		result.DeployDetails = status.DeployDetails
+		return status.State != ContainerAsyncStateQueued, nil
+	})
+	if err != nil {
+		return result, err
+	}
+
+	if !result.Success() {
+		return result, fmt.Errorf("%w: %s", ErrApexSaveFailed, result.failureSummary())
+	}
+
+	return result, nil
+}
+
+// failureSummary describes why the request didn't complete
+func (r *SaveApexResult) failureSummary() string {
+	var problems []string
+	for _, failure := range r.CompileErrors() {
+		problems = append(problems, fmt.Sprintf("%s line %d:%d: %s", failure.FullName, failure.LineNumber, failure.ColumnNumber, failure.Problem))
+	}
+	if len(problems) == 0 {
+		return fmt.Sprintf("state %s %s", r.State, r.ErrorMessage)
+	}
+	return strings.Join(problems, "; ")
+}
+
+// lookupApexEntityID returns the ID of the Apex class or trigger with the given name
+func (c *Client) lookupApexEntityID(entityType, name string) (string, error) {
+	var records []struct {
+		ID string `json:"Id"`
+	}
+	if err := c.queryToolingAll(fmt.Sprintf("SELECT Id FROM %s WHERE Name = '%s' AND NamespacePrefix = null",
+		entityType, escapeSOQLString(name)), &records); err != nil {
+		return "", err
+	}
+	if len(records) == 0 {
+		return "", fmt.Errorf("%s %s does not exist, create it with CreateToolingRecord first", entityType, name)
+	}
+	return records[0].ID, nil
+}
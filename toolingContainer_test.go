@@ -0,0 +1,108 @@
+package go_salesforce_api_client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newContainerTestServer(t *testing.T, asyncState string, calls *[]string) *httptest.Server {
+	var mu sync.Mutex
+	polls := 0
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		*calls = append(*calls, r.Method+" "+strings.TrimPrefix(r.URL.Path, "/services/data/v58.0/tooling/"))
+		switch {
+		case r.URL.Path == "/services/data/v58.0/tooling/query/":
+			_, _ = io.WriteString(w, `{"totalSize":1,"done":true,"records":[{"Id":"01p000000000001"}]}`)
+		case r.Method == http.MethodPost:
+			var record map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&record)
+			if strings.HasSuffix(r.URL.Path, "/ApexClassMember/") && record["ContentEntityId"] != "01p000000000001" {
+				t.Errorf("Unexpected member %v", record)
+			}
+			if strings.HasSuffix(r.URL.Path, "/ContainerAsyncRequest/") && record["IsCheckOnly"] != true {
+				t.Errorf("Expected check-only request, got %v", record)
+			}
+			w.WriteHeader(http.StatusCreated)
+			_, _ = io.WriteString(w, `{"id":"1dc000000000001","success":true,"errors":[]}`)
+		case r.Method == http.MethodGet:
+			polls++
+			state := "Queued"
+			if polls > 1 {
+				state = asyncState
+			}
+			_, _ = io.WriteString(w, `{"State":"`+state+`","ErrorMsg":null,"DeployDetails":{"componentFailures":[`)
+			if state == "Failed" {
+				_, _ = io.WriteString(w, `{"componentType":"ApexClass","fullName":"AccountService","lineNumber":3,"columnNumber":12,"problem":"Missing ';' at 'return'","problemType":"Error","success":false}`)
+			}
+			_, _ = io.WriteString(w, `],"componentSuccesses":[]}}`)
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+}
+
+func TestSaveApex(t *testing.T) {
+	t.Parallel()
+	var calls []string
+	server := newContainerTestServer(t, "Completed", &calls)
+	defer server.Close()
+
+	client := &Client{AccessToken: "mock_token", InstanceURL: server.URL}
+	result, err := client.SaveApex(context.Background(), []ApexSource{
+		{Type: "ApexClass", Name: "AccountService", Body: "public class AccountService {}"},
+	}, SaveApexOptions{CheckOnly: true, WaitOptions: WaitOptions{PollInterval: time.Millisecond}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !result.Success() || len(result.CompileErrors()) != 0 {
+		t.Errorf("Unexpected result %+v", result)
+	}
+
+	expected := []string{
+		"GET query/",
+		"POST sobjects/MetadataContainer/",
+		"POST sobjects/ApexClassMember/",
+		"POST sobjects/ContainerAsyncRequest/",
+		"GET sobjects/ContainerAsyncRequest/1dc000000000001",
+		"GET sobjects/ContainerAsyncRequest/1dc000000000001",
+		"DELETE sobjects/MetadataContainer/1dc000000000001",
+	}
+	if strings.Join(calls, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Unexpected calls:\n%s", strings.Join(calls, "\n"))
+	}
+}
+
+func TestSaveApexCompileError(t *testing.T) {
+	t.Parallel()
+	var calls []string
+	server := newContainerTestServer(t, "Failed", &calls)
+	defer server.Close()
+
+	client := &Client{AccessToken: "mock_token", InstanceURL: server.URL}
+	result, err := client.SaveApex(context.Background(), []ApexSource{
+		{Type: "ApexClass", Name: "AccountService", Body: "public class AccountService {", ContentEntityID: "01p000000000001"},
+	}, SaveApexOptions{CheckOnly: true, WaitOptions: WaitOptions{PollInterval: time.Millisecond}})
+	if !errors.Is(err, ErrApexSaveFailed) {
+		t.Fatalf("Expected ErrApexSaveFailed, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "AccountService line 3:12") {
+		t.Errorf("Expected location in error, got %v", err)
+	}
+	failures := result.CompileErrors()
+	if len(failures) != 1 || failures[0].LineNumber != 3 || failures[0].ColumnNumber != 12 {
+		t.Errorf("Unexpected compile errors %+v", failures)
+	}
+	if calls[0] != "POST sobjects/MetadataContainer/" || calls[len(calls)-1] != "DELETE sobjects/MetadataContainer/1dc000000000001" {
+		t.Errorf("Expected no lookup and container cleanup, got %v", calls)
+	}
+}
@@ -0,0 +1,568 @@
+package go_salesforce_api_client
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Trace flag log types
+const (
+	TraceFlagLogTypeUserDebug    = "USER_DEBUG"
+	TraceFlagLogTypeDeveloperLog = "DEVELOPER_LOG"
+	TraceFlagLogTypeClassTracing = "CLASS_TRACING"
+)
+
+// Apex debug log event types understood by ParseApexLog
+const (
+	ApexLogEventUserDebug        = "USER_DEBUG"
+	ApexLogEventSOQLExecuteBegin = "SOQL_EXECUTE_BEGIN"
+	ApexLogEventSOQLExecuteEnd   = "SOQL_EXECUTE_END"
+	ApexLogEventExceptionThrown  = "EXCEPTION_THROWN"
+	ApexLogEventFatalError       = "FATAL_ERROR"
+	ApexLogEventLimitUsage       = "LIMIT_USAGE"
+	ApexLogEventLimitUsageForNS  = "LIMIT_USAGE_FOR_NS"
+)
+
+// maxTraceFlagDuration is the longest lifetime Salesforce allows for a trace flag
+const maxTraceFlagDuration = 24 * time.Hour
+
+// TraceFlag represents a Tooling API TraceFlag record
+type TraceFlag struct {
+	ID             string
+	TracedEntityID string
+	DebugLevelID   string
+	LogType        string
+	StartDate      time.Time
+	ExpirationDate time.Time
+}
+
+// TraceFlagOptions configures SetTraceFlag
+type TraceFlagOptions struct {
+	TracedEntityID string        // User, Apex class or trigger ID, defaults to the current user
+	LogType        string        // Defaults to TraceFlagLogTypeUserDebug
+	DebugLevel     *DebugLevel   // Created when no DebugLevel with its DeveloperName exists, defaults to DefaultDebugLevel("GoSalesforceApiClient")
+	Duration       time.Duration // Defaults to 1 hour, at most 24 hours
+}
+
+// ApexLog represents a Tooling API ApexLog record
+type ApexLog struct {
+	ID                   string
+	LogUserID            string
+	Operation            string
+	Request              string
+	Application          string
+	Status               string
+	Location             string
+	LogLength            int
+	DurationMilliseconds int
+	StartTime            time.Time
+}
+
+// ApexLogListOptions filters ListApexLogs
+type ApexLogListOptions struct {
+	UserID      string    // Only logs of this user
+	Since       time.Time // Only logs started at or after this time
+	Limit       int       // Defaults to 100, negative for all matching logs
+	OldestFirst bool      // Order by start time ascending instead of newest first
+}
+
+// ApexLogTailOptions configures TailApexLogs
+type ApexLogTailOptions struct {
+	UserID       string        // Only logs of this user
+	Since        time.Time     // Defaults to the time tailing starts
+	PollInterval time.Duration // Defaults to 5 seconds
+}
+
+// ApexLimitUsage represents the consumption of a single governor limit
+type ApexLimitUsage struct {
+	Used int
+	Max  int
+}
+
+// ApexLogEvent represents a single event of an Apex debug log. Type specific
+// fields are only set for the matching event types.
+type ApexLogEvent struct {
+	Timestamp  string        // Wall clock time, e.g. 13:45:00.037
+	Elapsed    time.Duration // Time since the start of the request
+	Type       string
+	LineNumber int      // Apex source line, 0 when not reported
+	Fields     []string // Remaining pipe separated fields
+
+	Level         string                    // USER_DEBUG
+	Message       string                    // USER_DEBUG, EXCEPTION_THROWN and FATAL_ERROR
+	ExceptionType string                    // EXCEPTION_THROWN and FATAL_ERROR
+	Query         string                    // SOQL_EXECUTE_BEGIN
+	Rows          int                       // SOQL_EXECUTE_END
+	Namespace     string                    // LIMIT_USAGE_FOR_NS
+	Limits        map[string]ApexLimitUsage // LIMIT_USAGE and LIMIT_USAGE_FOR_NS
+}
+
+// traceFlagRecord is the Tooling API representation of a TraceFlag
+type traceFlagRecord struct {
+	ID             string `json:"Id,omitempty"`
+	TracedEntityID string `json:"TracedEntityId,omitempty"`
+	DebugLevelID   string `json:"DebugLevelId,omitempty"`
+	LogType        string `json:"LogType,omitempty"`
+	StartDate      string `json:"StartDate,omitempty"`
+	ExpirationDate string `json:"ExpirationDate,omitempty"`
+}
+
+// toTraceFlag converts the record, ignoring unparsable dates
+func (r traceFlagRecord) toTraceFlag() TraceFlag {
+	flag := TraceFlag{
+		ID:             r.ID,
+		TracedEntityID: r.TracedEntityID,
+		DebugLevelID:   r.DebugLevelID,
+		LogType:        r.LogType,
+	}
+	flag.StartDate, _ = parseSalesforceTime(r.StartDate)
+	flag.ExpirationDate, _ = parseSalesforceTime(r.ExpirationDate)
+	return flag
+}
+
+// GetDebugLevel looks up a DebugLevel by developer name and returns nil when it doesn't exist
+func (c *Client) GetDebugLevel(developerName string) (*DebugLevel, error) {
+	var levels []DebugLevel
+	if err := c.queryToolingAll(fmt.Sprintf(
+		"SELECT Id, DeveloperName, MasterLabel, ApexCode, ApexProfiling, Callout, Database, System, Validation, Visualforce, Workflow FROM DebugLevel WHERE DeveloperName = '%s'",
+		escapeSOQLString(developerName)), &levels); err != nil {
+		return nil, err
+	}
+	if len(levels) == 0 {
+		return nil, nil
+	}
+	return &levels[0], nil
+}
+
+// CreateDebugLevel creates a DebugLevel and returns its ID
+func (c *Client) CreateDebugLevel(level DebugLevel) (string, error) {
+	level.ID = ""
+	resp, err := c.CreateToolingRecord("DebugLevel", level)
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// UpdateDebugLevel updates the log levels of an existing DebugLevel identified by its ID
+func (c *Client) UpdateDebugLevel(level DebugLevel) error {
+	if level.ID == "" {
+		return errors.New("debug level ID is required")
+	}
+	id := level.ID
+	level.ID = ""
+	return c.UpdateToolingRecord("DebugLevel", id, level)
+}
+
+// ListTraceFlags lists the trace flags of a user, Apex class or trigger
+func (c *Client) ListTraceFlags(tracedEntityID string) ([]TraceFlag, error) {
+	var records []traceFlagRecord
+	if err := c.queryToolingAll(fmt.Sprintf(
+		"SELECT Id, TracedEntityId, DebugLevelId, LogType, StartDate, ExpirationDate FROM TraceFlag WHERE TracedEntityId = '%s' ORDER BY ExpirationDate DESC",
+		escapeSOQLString(tracedEntityID)), &records); err != nil {
+		return nil, err
+	}
+
+	flags := make([]TraceFlag, 0, len(records))
+	for _, record := range records {
+		flags = append(flags, record.toTraceFlag())
+	}
+	return flags, nil
+}
+
+// ExtendTraceFlag moves the expiration date of a trace flag
+func (c *Client) ExtendTraceFlag(traceFlagID string, expiration time.Time) error {
+	return c.UpdateToolingRecord("TraceFlag", traceFlagID, traceFlagRecord{
+		ExpirationDate: expiration.UTC().Format(toolingTimeLayout),
+	})
+}
+
+// SetTraceFlag enables debug logging for a user, Apex class or trigger. An
+// existing trace flag of the same log type is extended and switched to the
+// debug level, otherwise a new one is created.
+func (c *Client) SetTraceFlag(options TraceFlagOptions) (*TraceFlag, error) {
+	entityID := options.TracedEntityID
+	if entityID == "" {
+		userID, err := c.currentUserID()
+		if err != nil {
+			return nil, err
+		}
+		entityID = userID
+	}
+
+	logType := options.LogType
+	if logType == "" {
+		logType = TraceFlagLogTypeUserDebug
+	}
+
+	duration := options.Duration
+	if duration <= 0 {
+		duration = time.Hour
+	}
+	if duration > maxTraceFlagDuration {
+		duration = maxTraceFlagDuration
+	}
+
+	level := DefaultDebugLevel("GoSalesforceApiClient")
+	if options.DebugLevel != nil {
+		level = *options.DebugLevel
+	}
+	debugLevelID, err := c.ensureDebugLevel(level)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	flag := TraceFlag{
+		TracedEntityID: entityID,
+		DebugLevelID:   debugLevelID,
+		LogType:        logType,
+		StartDate:      now,
+		ExpirationDate: now.Add(duration),
+	}
+
+	existing, err := c.ListTraceFlags(entityID)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range existing {
+		if e.LogType != logType {
+			continue
+		}
+		flag.ID = e.ID
+		if err := c.UpdateToolingRecord("TraceFlag", e.ID, traceFlagRecord{
+			DebugLevelID:   debugLevelID,
+			StartDate:      flag.StartDate.Format(toolingTimeLayout),
+			ExpirationDate: flag.ExpirationDate.Format(toolingTimeLayout),
+		}); err != nil {
+			return nil, err
+		}
+		return &flag, nil
+	}
+
+	resp, err := c.CreateToolingRecord("TraceFlag", traceFlagRecord{
+		TracedEntityID: entityID,
+		DebugLevelID:   debugLevelID,
+		LogType:        logType,
+		StartDate:      flag.StartDate.Format(toolingTimeLayout),
+		ExpirationDate: flag.ExpirationDate.Format(toolingTimeLayout),
+	})
+	if err != nil {
+		return nil, err
+	}
+	flag.ID = resp.ID
+	return &flag, nil
+}
+
+// ensureDebugLevel returns the ID of the given debug level, creating it when
+// no DebugLevel with its developer name exists yet
+func (c *Client) ensureDebugLevel(level DebugLevel) (string, error) {
+	if level.ID != "" {
+		return level.ID, nil
+	}
+	existing, err := c.GetDebugLevel(level.DeveloperName)
+	if err != nil {
+		return "", err
+	}
+	if existing != nil {
+		return existing.ID, nil
+	}
+	return c.CreateDebugLevel(level)
+}
+
+// ListApexLogs lists debug logs, newest first unless OldestFirst is set
+func (c *Client) ListApexLogs(options ApexLogListOptions) ([]ApexLog, error) {
+	limit := options.Limit
+	if limit == 0 {
+		limit = 100
+	}
+
+	var conditions []string
+	if options.UserID != "" {
+		conditions = append(conditions, fmt.Sprintf("LogUserId = '%s'", escapeSOQLString(options.UserID)))
+	}
+	if !options.Since.IsZero() {
+		conditions = append(conditions, "StartTime >= "+options.Since.UTC().Format(time.RFC3339))
+	}
+
+	soql := "SELECT Id, LogUserId, Operation, Request, Application, Status, Location, LogLength, DurationMilliseconds, StartTime FROM ApexLog"
+	if len(conditions) > 0 {
+		soql += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	if options.OldestFirst {
+		soql += " ORDER BY StartTime ASC"
+	} else {
+		soql += " ORDER BY StartTime DESC"
+	}
+	if limit > 0 {
+		soql += fmt.Sprintf(" LIMIT %d", limit)
+	}
+
+	var records []struct {
+		ID                   string `json:"Id"`
+		LogUserID            string `json:"LogUserId"`
+		Operation            string `json:"Operation"`
+		Request              string `json:"Request"`
+		Application          string `json:"Application"`
+		Status               string `json:"Status"`
+		Location             string `json:"Location"`
+		LogLength            int    `json:"LogLength"`
+		DurationMilliseconds int    `json:"DurationMilliseconds"`
+		StartTime            string `json:"StartTime"`
+	}
+	if err := c.queryToolingAll(soql, &records); err != nil {
+		return nil, err
+	}
+
+	logs := make([]ApexLog, 0, len(records))
+	for _, r := range records {
+		startTime, _ := parseSalesforceTime(r.StartTime)
+		logs = append(logs, ApexLog{
+			ID:                   r.ID,
+			LogUserID:            r.LogUserID,
+			Operation:            r.Operation,
+			Request:              r.Request,
+			Application:          r.Application,
+			Status:               r.Status,
+			Location:             r.Location,
+			LogLength:            r.LogLength,
+			DurationMilliseconds: r.DurationMilliseconds,
+			StartTime:            startTime,
+		})
+	}
+	return logs, nil
+}
+
+// GetApexLogBody downloads the raw content of a debug log
+func (c *Client) GetApexLogBody(logID string) (string, error) {
+	var body strings.Builder
+	if err := c.DownloadApexLog(logID, &body); err != nil {
+		return "", err
+	}
+	return body.String(), nil
+}
+
+// DownloadApexLog streams the raw content of a debug log to w
+func (c *Client) DownloadApexLog(logID string, w io.Writer) error {
+	if c.AccessToken == "" || c.InstanceURL == "" {
+		return errors.New("missing authentication details")
+	}
+
+	url := fmt.Sprintf("%s/services/data/v58.0/tooling/sobjects/ApexLog/%s/Body", c.InstanceURL, logID)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.AccessToken)
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to retrieve apex log body, status: %d, response: %s", resp.StatusCode, string(body))
+	}
+
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+// TailApexLogs polls for new debug logs and passes each one with its body to
+// the callback in start order. It runs until the context is cancelled or the
+// callback returns an error.
+func (c *Client) TailApexLogs(ctx context.Context, options ApexLogTailOptions, callback func(log ApexLog, body string) error) error {
+	interval := options.PollInterval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	since := options.Since
+	if since.IsZero() {
+		since = time.Now()
+	}
+	// StartTime has second precision in the filter, so the logs of the last
+	// second are listed again on the next poll and skipped by ID
+	seen := map[string]time.Time{}
+
+	for {
+		// List every log since the last poll so bursts aren't cut off by a limit
+		logs, err := c.ListApexLogs(ApexLogListOptions{UserID: options.UserID, Since: since, Limit: -1, OldestFirst: true})
+		if err != nil {
+			return err
+		}
+
+		sort.SliceStable(logs, func(i, j int) bool { return logs[i].StartTime.Before(logs[j].StartTime) })
+		for _, log := range logs {
+			if _, ok := seen[log.ID]; ok {
+				continue
+			}
+			body, err := c.GetApexLogBody(log.ID)
+			if err != nil {
+				return err
+			}
+			if err := callback(log, body); err != nil {
+				return err
+			}
+			seen[log.ID] = log.StartTime
+			if log.StartTime.Truncate(time.Second).After(since) {
+				since = log.StartTime.Truncate(time.Second)
+			}
+		}
+
+		for id, startTime := range seen {
+			if startTime.Before(since) {
+				delete(seen, id)
+			}
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+var (
+	apexLogLinePattern  = regexp.MustCompile(`^(\d{2}:\d{2}:\d{2}\.\d+) \((\d+)\)\|([A-Z_]+)(?:\|(.*))?$`)
+	apexLogLimitPattern = regexp.MustCompile(`^\s*(.+?): (\d+) out of (\d+)`)
+	apexLogLineNumber   = regexp.MustCompile(`^\[(\d+)\]$`)
+)
+
+// ParseApexLog splits a debug log into events. Lines that don't start with a
+// timestamp continue the message of the preceding event.
+func ParseApexLog(r io.Reader) ([]ApexLogEvent, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 32*1024*1024)
+
+	var events []ApexLogEvent
+	var current *ApexLogEvent
+	for scanner.Scan() {
+		line := scanner.Text()
+		match := apexLogLinePattern.FindStringSubmatch(line)
+		if match == nil {
+			if current != nil {
+				current.appendLine(line)
+			}
+			continue
+		}
+
+		if current != nil {
+			events = append(events, *current)
+		}
+		current = newApexLogEvent(match)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if current != nil {
+		events = append(events, *current)
+	}
+
+	return events, nil
+}
+
+// newApexLogEvent builds an event from a matched log line
+func newApexLogEvent(match []string) *ApexLogEvent {
+	nanos, _ := strconv.ParseInt(match[2], 10, 64)
+	event := &ApexLogEvent{
+		Timestamp: match[1],
+		Elapsed:   time.Duration(nanos),
+		Type:      match[3],
+	}
+
+	var fields []string
+	if match[4] != "" {
+		fields = strings.Split(match[4], "|")
+	}
+	if len(fields) > 0 {
+		if m := apexLogLineNumber.FindStringSubmatch(fields[0]); m != nil {
+			event.LineNumber, _ = strconv.Atoi(m[1])
+			fields = fields[1:]
+		}
+	}
+	event.Fields = fields
+
+	switch event.Type {
+	case ApexLogEventUserDebug:
+		if len(fields) > 0 {
+			event.Level = fields[0]
+			event.Message = strings.Join(fields[1:], "|")
+		}
+	case ApexLogEventSOQLExecuteBegin:
+		if len(fields) > 0 {
+			event.Query = fields[len(fields)-1]
+		}
+	case ApexLogEventSOQLExecuteEnd:
+		for _, field := range fields {
+			if rows, ok := strings.CutPrefix(field, "Rows:"); ok {
+				event.Rows, _ = strconv.Atoi(rows)
+			}
+		}
+	case ApexLogEventExceptionThrown, ApexLogEventFatalError:
+		event.Message = strings.Join(fields, "|")
+		if exceptionType, _, ok := strings.Cut(event.Message, ":"); ok && !strings.Contains(exceptionType, " ") {
+			event.ExceptionType = exceptionType
+		}
+	case ApexLogEventLimitUsage:
+		// LIMIT_USAGE|[line]|SOQL|1|100
+		if len(fields) >= 3 {
+			used, _ := strconv.Atoi(fields[1])
+			max, _ := strconv.Atoi(fields[2])
+			event.Limits = map[string]ApexLimitUsage{fields[0]: {Used: used, Max: max}}
+		}
+	case ApexLogEventLimitUsageForNS:
+		if len(fields) > 0 {
+			event.Namespace = fields[0]
+		}
+		event.Limits = map[string]ApexLimitUsage{}
+	}
+
+	return event
+}
+
+// appendLine adds a continuation line to the event
+func (e *ApexLogEvent) appendLine(line string) {
+	switch e.Type {
+	case ApexLogEventLimitUsageForNS:
+		if m := apexLogLimitPattern.FindStringSubmatch(line); m != nil {
+			used, _ := strconv.Atoi(m[2])
+			max, _ := strconv.Atoi(m[3])
+			e.Limits[m[1]] = ApexLimitUsage{Used: used, Max: max}
+		}
+	case ApexLogEventUserDebug, ApexLogEventExceptionThrown, ApexLogEventFatalError:
+		e.Message += "\n" + line
+	case ApexLogEventSOQLExecuteBegin:
+		e.Query += "\n" + line
+	}
+}
+
+// FilterApexLogEvents returns the events of the given types
+func FilterApexLogEvents(events []ApexLogEvent, types ...string) []ApexLogEvent {
+	var filtered []ApexLogEvent
+	for _, event := range events {
+		for _, t := range types {
+			if event.Type == t {
+				filtered = append(filtered, event)
+				break
+			}
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,166 @@
+package go_salesforce_api_client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const sampleApexLog = `58.0 APEX_CODE,FINEST;APEX_PROFILING,INFO
+13:45:00.001 (1000000)|EXECUTION_STARTED
+13:45:00.037 (37446573)|USER_DEBUG|[3]|DEBUG|first line
+second line
+13:45:00.040 (40136020)|SOQL_EXECUTE_BEGIN|[5]|Aggregations:0|SELECT Id FROM Account
+13:45:00.045 (45000000)|SOQL_EXECUTE_END|[5]|Rows:3
+13:45:00.046 (46000000)|LIMIT_USAGE|[5]|SOQL|1|100
+13:45:00.050 (50000000)|EXCEPTION_THROWN|[7]|System.NullPointerException: Attempt to de-reference a null object
+13:45:00.070 (70000000)|LIMIT_USAGE_FOR_NS|(default)|
+  Number of SOQL queries: 1 out of 100
+  Maximum CPU time: 12 out of 10000 ******* CLOSE TO LIMIT
+13:45:00.080 (80000000)|EXECUTION_FINISHED
+`
+
+func TestParseApexLog(t *testing.T) {
+	t.Parallel()
+	events, err := ParseApexLog(strings.NewReader(sampleApexLog))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(events) != 8 {
+		t.Fatalf("Expected 8 events, got %d", len(events))
+	}
+
+	debug := FilterApexLogEvents(events, ApexLogEventUserDebug)
+	if len(debug) != 1 || debug[0].Level != "DEBUG" || debug[0].Message != "first line\nsecond line" || debug[0].LineNumber != 3 {
+		t.Errorf("Unexpected USER_DEBUG event %+v", debug)
+	}
+	if debug[0].Elapsed != 37446573*time.Nanosecond || debug[0].Timestamp != "13:45:00.037" {
+		t.Errorf("Unexpected timing %s %s", debug[0].Timestamp, debug[0].Elapsed)
+	}
+
+	soql := FilterApexLogEvents(events, ApexLogEventSOQLExecuteBegin, ApexLogEventSOQLExecuteEnd)
+	if len(soql) != 2 || soql[0].Query != "SELECT Id FROM Account" || soql[1].Rows != 3 {
+		t.Errorf("Unexpected SOQL events %+v", soql)
+	}
+
+	exception := FilterApexLogEvents(events, ApexLogEventExceptionThrown)[0]
+	if exception.ExceptionType != "System.NullPointerException" || exception.LineNumber != 7 {
+		t.Errorf("Unexpected exception event %+v", exception)
+	}
+
+	if usage := FilterApexLogEvents(events, ApexLogEventLimitUsage)[0]; usage.Limits["SOQL"] != (ApexLimitUsage{Used: 1, Max: 100}) {
+		t.Errorf("Unexpected LIMIT_USAGE event %+v", usage)
+	}
+	ns := FilterApexLogEvents(events, ApexLogEventLimitUsageForNS)[0]
+	if ns.Namespace != "(default)" || ns.Limits["Maximum CPU time"] != (ApexLimitUsage{Used: 12, Max: 10000}) || len(ns.Limits) != 2 {
+		t.Errorf("Unexpected LIMIT_USAGE_FOR_NS event %+v", ns)
+	}
+}
+
+func TestSetTraceFlag(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	var patched map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		q := r.URL.Query().Get("q")
+		switch {
+		case strings.Contains(q, "FROM DebugLevel"):
+			if !strings.Contains(q, "DeveloperName = 'O\\'Brien'") {
+				t.Errorf("Expected escaped developer name in %s", q)
+			}
+			_, _ = io.WriteString(w, `{"totalSize":1,"done":true,"records":[{"Id":"7dl000000000001","DeveloperName":"O'Brien"}]}`)
+		case strings.Contains(q, "FROM TraceFlag"):
+			_, _ = io.WriteString(w, `{"totalSize":2,"done":true,"records":[
+				{"Id":"7tf000000000001","TracedEntityId":"005ME","LogType":"DEVELOPER_LOG","ExpirationDate":"2024-01-01T00:00:00.000+0000"},
+				{"Id":"7tf000000000002","TracedEntityId":"005ME","LogType":"USER_DEBUG","ExpirationDate":"2024-01-01T00:00:00.000+0000"}]}`)
+		case r.Method == http.MethodPatch && r.URL.Path == "/services/data/v58.0/tooling/sobjects/TraceFlag/7tf000000000002":
+			_ = json.NewDecoder(r.Body).Decode(&patched)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected request %s %s %s", r.Method, r.URL.Path, q)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{AccessToken: "mock_token", InstanceURL: server.URL}
+	level := DefaultDebugLevel("O'Brien")
+	flag, err := client.SetTraceFlag(TraceFlagOptions{TracedEntityID: "005ME", DebugLevel: &level, Duration: 48 * time.Hour})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if flag.ID != "7tf000000000002" || flag.DebugLevelID != "7dl000000000001" {
+		t.Errorf("Unexpected trace flag %+v", flag)
+	}
+	if got := flag.ExpirationDate.Sub(flag.StartDate); got != 24*time.Hour {
+		t.Errorf("Expected duration capped at 24h, got %s", got)
+	}
+	if patched["DebugLevelId"] != "7dl000000000001" || patched["ExpirationDate"] == nil || patched["LogType"] != nil {
+		t.Errorf("Unexpected update payload %v", patched)
+	}
+}
+
+func TestTailApexLogs(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.URL.Path == "/services/data/v58.0/tooling/query/":
+			q := r.URL.Query().Get("q")
+			if !strings.Contains(q, "LogUserId = '005ME'") || !strings.Contains(q, "StartTime >= 2024-05-01T10:00:0") ||
+				!strings.HasSuffix(q, "ORDER BY StartTime ASC") {
+				t.Errorf("Unexpected query %s", q)
+			}
+			polls++
+			records := `{"Id":"07L1","LogUserId":"005ME","Operation":"/apex/Page","StartTime":"2024-05-01T10:00:01.000+0000","LogLength":10}`
+			if polls > 1 {
+				records += `,{"Id":"07L2","LogUserId":"005ME","StartTime":"2024-05-01T10:00:05.000+0000"}`
+			}
+			_, _ = io.WriteString(w, `{"totalSize":2,"done":true,"records":[`+records+`]}`)
+		case strings.HasPrefix(r.URL.Path, "/services/data/v58.0/tooling/sobjects/ApexLog/"):
+			_, _ = io.WriteString(w, "body of "+strings.Split(r.URL.Path, "/")[7])
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{AccessToken: "mock_token", InstanceURL: server.URL}
+	var bodies []string
+	stop := errors.New("stop")
+	err := client.TailApexLogs(context.Background(), ApexLogTailOptions{
+		UserID:       "005ME",
+		Since:        time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		PollInterval: time.Millisecond,
+	}, func(log ApexLog, body string) error {
+		bodies = append(bodies, body)
+		if len(bodies) == 2 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) {
+		t.Fatalf("Expected callback error, got %v", err)
+	}
+	if strings.Join(bodies, ",") != "body of 07L1,body of 07L2" {
+		t.Errorf("Expected each log once in start order, got %v", bodies)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = client.TailApexLogs(ctx, ApexLogTailOptions{UserID: "005ME", Since: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)},
+		func(ApexLog, string) error { return nil })
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,131 @@
+package go_salesforce_api_client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// sendToolingSObjectRequest sends a request to /tooling/sobjects/{path} and
+// returns the response body when the status matches expectedStatus
+func (c *Client) sendToolingSObjectRequest(method, path string, payload any, expectedStatus int) ([]byte, error) {
+	if c.AccessToken == "" || c.InstanceURL == "" {
+		return nil, errors.New("missing authentication details")
+	}
+
+	url := fmt.Sprintf("%s/services/data/v58.0/tooling/sobjects/%s", c.InstanceURL, path)
+
+	var reqBody io.Reader
+	if payload != nil {
+		jsonData, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = bytes.NewBuffer(jsonData)
+	}
+
+	req, err := http.NewRequest(method, url, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.AccessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != expectedStatus {
+		return nil, fmt.Errorf("tooling request %s %s failed, status: %d, response: %s", method, path, resp.StatusCode, string(body))
+	}
+
+	return body, nil
+}
+
+// CreateToolingRecord creates a Tooling API sObject record such as an
+// ApexClass, ValidationRule, TraceFlag or DebugLevel
+func (c *Client) CreateToolingRecord(objectType string, record any) (*SobjectResponse, error) {
+	body, err := c.sendToolingSObjectRequest(http.MethodPost, objectType+"/", record, http.StatusCreated)
+	if err != nil {
+		return nil, err
+	}
+
+	var sfResp SobjectResponse
+	if err := json.Unmarshal(body, &sfResp); err != nil {
+		return nil, err
+	}
+
+	return &sfResp, nil
+}
+
+// GetToolingRecord retrieves a Tooling API sObject record by ID
+func (c *Client) GetToolingRecord(objectType, recordID string) (map[string]interface{}, error) {
+	var record map[string]interface{}
+	if err := c.GetToolingRecordInto(objectType, recordID, &record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// GetToolingRecordInto retrieves a Tooling API sObject record by ID and
+// decodes it into v
+func (c *Client) GetToolingRecordInto(objectType, recordID string, v any) error {
+	body, err := c.sendToolingSObjectRequest(http.MethodGet, objectType+"/"+recordID, nil, http.StatusOK)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
+
+// UpdateToolingRecord updates a Tooling API sObject record by ID
+func (c *Client) UpdateToolingRecord(objectType, recordID string, updates any) error {
+	_, err := c.sendToolingSObjectRequest(http.MethodPatch, objectType+"/"+recordID, updates, http.StatusNoContent)
+	return err
+}
+
+// DeleteToolingRecord deletes a Tooling API sObject record by ID
+func (c *Client) DeleteToolingRecord(objectType, recordID string) error {
+	_, err := c.sendToolingSObjectRequest(http.MethodDelete, objectType+"/"+recordID, nil, http.StatusNoContent)
+	return err
+}
+
+// DescribeToolingSObject retrieves metadata for a given Tooling API object
+func (c *Client) DescribeToolingSObject(objectType string) (map[string]interface{}, error) {
+	body, err := c.sendToolingSObjectRequest(http.MethodGet, objectType+"/describe", nil, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+
+	var description map[string]interface{}
+	if err := json.Unmarshal(body, &description); err != nil {
+		return nil, err
+	}
+
+	return description, nil
+}
+
+// DescribeToolingGlobal lists the objects available in the Tooling API
+func (c *Client) DescribeToolingGlobal() (map[string]interface{}, error) {
+	body, err := c.sendToolingSObjectRequest(http.MethodGet, "", nil, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+
+	var description map[string]interface{}
+	if err := json.Unmarshal(body, &description); err != nil {
+		return nil, err
+	}
+
+	return description, nil
+}
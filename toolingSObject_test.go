@@ -0,0 +1,104 @@
+package go_salesforce_api_client
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestToolingRecordCRUD(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /services/data/v58.0/tooling/sobjects/ValidationRule/":
+			var record map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&record)
+			if record["FullName"] != "Account.Name_Required" {
+				t.Errorf("Unexpected record %v", record)
+			}
+			w.WriteHeader(http.StatusCreated)
+			_, _ = io.WriteString(w, `{"id":"03d000000000001","success":true,"errors":[]}`)
+		case "GET /services/data/v58.0/tooling/sobjects/ValidationRule/03d000000000001":
+			_, _ = io.WriteString(w, `{"Id":"03d000000000001","ValidationName":"Name_Required","Active":true}`)
+		case "PATCH /services/data/v58.0/tooling/sobjects/ValidationRule/03d000000000001",
+			"DELETE /services/data/v58.0/tooling/sobjects/ValidationRule/03d000000000001":
+			w.WriteHeader(http.StatusNoContent)
+		case "GET /services/data/v58.0/tooling/sobjects/ValidationRule/describe":
+			_, _ = io.WriteString(w, `{"name":"ValidationRule","fields":[{"name":"Id"}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `[{"errorCode":"NOT_FOUND","message":"The requested resource does not exist"}]`)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{AccessToken: "mock_token", InstanceURL: server.URL}
+
+	created, err := client.CreateToolingRecord("ValidationRule", map[string]interface{}{
+		"FullName": "Account.Name_Required",
+		"Metadata": map[string]interface{}{"active": true, "errorConditionFormula": "ISBLANK(Name)", "errorMessage": "Required"},
+	})
+	if err != nil || created.ID != "03d000000000001" {
+		t.Fatalf("Unexpected create result %+v, %v", created, err)
+	}
+
+	var rule struct {
+		ValidationName string
+		Active         bool
+	}
+	if err := client.GetToolingRecordInto("ValidationRule", created.ID, &rule); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if rule.ValidationName != "Name_Required" || !rule.Active {
+		t.Errorf("Unexpected record %+v", rule)
+	}
+
+	if err := client.UpdateToolingRecord("ValidationRule", created.ID, map[string]interface{}{"Metadata": map[string]interface{}{"active": false}}); err != nil {
+		t.Errorf("Expected no error on update, got %v", err)
+	}
+
+	description, err := client.DescribeToolingSObject("ValidationRule")
+	if err != nil || description["name"] != "ValidationRule" {
+		t.Errorf("Unexpected describe result %v, %v", description, err)
+	}
+
+	if err := client.DeleteToolingRecord("ValidationRule", created.ID); err != nil {
+		t.Errorf("Expected no error on delete, got %v", err)
+	}
+
+	if _, err := client.GetToolingRecord("ApexClass", "01p000000000001"); err == nil {
+		t.Error("Expected error for missing record")
+	}
+}
+
+func TestCustomFieldMetadataJSON(t *testing.T) {
+	t.Parallel()
+	field := CustomField{
+		FullName: "Case.Priority_Level__c",
+		Metadata: CustomFieldMetadata{
+			Label:       "Priority Level",
+			Type:        "Picklist",
+			Required:    true,
+			Description: "Customer priority",
+			ValueSet:    NewPicklistValueSet("Low", "High"),
+		},
+	}
+	field.Metadata.ValueSet.ValueSetDefinition.Value[0].Default = true
+
+	data, err := json.Marshal(field)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := `{"FullName":"Case.Priority_Level__c","Metadata":{"label":"Priority Level","type":"Picklist","description":"Customer priority","required":true,` +
+		`"valueSet":{"restricted":true,"valueSetDefinition":{"sorted":false,"value":[{"fullName":"Low","label":"Low","default":true},{"fullName":"High","label":"High","default":false}]}}}}`
+	if string(data) != expected {
+		t.Errorf("Unexpected JSON:\n got %s\nwant %s", data, expected)
+	}
+
+	lookup, _ := json.Marshal(CustomFieldMetadata{Label: "Account", Type: "Lookup", ReferenceTo: "Account", RelationshipName: "Cases", DeleteConstraint: "SetNull"})
+	if string(lookup) != `{"label":"Account","type":"Lookup","referenceTo":"Account","relationshipName":"Cases","deleteConstraint":"SetNull"}` {
+		t.Errorf("Unexpected lookup JSON %s", lookup)
+	}
+}
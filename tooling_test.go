@@ -76,11 +76,7 @@ func TestCreateCustomField(t *testing.T) {
 
 	fieldData := CustomField{
 		FullName: "Account.Custom_Field__c",
-		Metadata: struct {
-			Label  string `json:"label"`
-			Type   string `json:"type"`
-			Length int    `json:"length,omitempty"`
-		}{
+		Metadata: CustomFieldMetadata{
 			Label: "Custom Field",
 			Type:  "Text",
 		},
@@ -0,0 +1,71 @@
+package go_salesforce_api_client
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// WaitOptions configures how asynchronous operations are polled
+type WaitOptions struct {
+	PollInterval    time.Duration // Delay before the second poll, defaults to 2s
+	MaxPollInterval time.Duration // Upper bound for the delay, defaults to 30s
+	BackoffFactor   float64       // Multiplier applied after each poll, defaults to 1.5; use 1 for a fixed interval
+	Timeout         time.Duration // Overall timeout, zero means no timeout besides the context
+}
+
+// withDefaults fills in unset wait options
+func (o WaitOptions) withDefaults() WaitOptions {
+	if o.PollInterval <= 0 {
+		o.PollInterval = 2 * time.Second
+	}
+	if o.MaxPollInterval <= 0 {
+		o.MaxPollInterval = 30 * time.Second
+	}
+	if o.MaxPollInterval < o.PollInterval {
+		o.MaxPollInterval = o.PollInterval
+	}
+	if o.BackoffFactor < 1 {
+		o.BackoffFactor = 1.5
+	}
+	return o
+}
+
+// poll calls check until it reports done, returns an error, or the context
+// or timeout expires. The delay between calls grows by the backoff factor.
+func poll(ctx context.Context, options WaitOptions, check func() (bool, error)) error {
+	options = options.withDefaults()
+
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+
+	interval := options.PollInterval
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("stopped waiting: %w", ctx.Err())
+		case <-timer.C:
+			// Both channels may be ready, don't poll once the context is done
+			if err := ctx.Err(); err != nil {
+				return fmt.Errorf("stopped waiting: %w", err)
+			}
+		}
+
+		done, err := check()
+		if err != nil || done {
+			return err
+		}
+
+		timer.Reset(interval)
+		interval = time.Duration(float64(interval) * options.BackoffFactor)
+		if interval > options.MaxPollInterval {
+			interval = options.MaxPollInterval
+		}
+	}
+}